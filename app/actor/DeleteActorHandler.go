@@ -2,6 +2,8 @@ package actor
 
 import (
 	"context"
+
+	"github.com/EmreZURNACI/apistack/i18n"
 )
 
 type DeleteActorRequest struct {
@@ -28,7 +30,7 @@ func (h *DeleteActorHandler) Handle(ctx context.Context, req *DeleteActorRequest
 		return &DeleteActorResponse{}, err
 	}
 	return &DeleteActorResponse{
-		Message: "Aktör silindi",
+		Message: i18n.T(ctx, "actor_deleted"),
 	}, nil
 
 }
@@ -2,38 +2,180 @@ package actor
 
 import (
 	"context"
+	"slices"
 
 	"github.com/EmreZURNACI/apistack/domain"
 )
 
 type GetActorsRequest struct {
-	Search  string `json:"search"`
-	Limit   int    `json:"limit"`
-	Offset  int    `json:"offset"`
-	OrderBy bool   `json:"order_by"`
+	Search string `json:"search"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+	// OrderBy, eski istemciler içindir ve sort=-id ile aynıdır; Sort
+	// verilmişse yok sayılır.
+	OrderBy bool `json:"order_by"`
+	// Sort, "last_name,-last_update" biçiminde sıralamadır.
+	Sort   string             `json:"sort"`
+	Filter domain.ActorFilter `json:"filter"`
+	Cursor string             `json:"cursor"`
+	// Total, toplam kayıt sayısının nasıl hesaplanacağıdır: TotalExact,
+	// TotalEstimate veya TotalNone. Boşsa TotalExact kullanılır.
+	Total string `json:"total"`
+	// SearchMode, SearchContains veya SearchFuzzy'dir. Boşsa
+	// SearchContains kullanılır.
+	SearchMode string `json:"search_mode"`
+	// WithScore, bulanık aramada benzerlik puanının kayıtlarla dönülmesini
+	// sağlar.
+	WithScore bool `json:"with_score"`
 }
+
+const (
+	// SearchContains, ad veya soyadın aramayı içermesidir.
+	SearchContains = "contains"
+	// SearchFuzzy, aksan ve yazım hatalarına toleranslı benzerlik
+	// aramasıdır; sonuçlar varsayılan olarak benzerliğe göre sıralanır.
+	SearchFuzzy = "fuzzy"
+)
+
+const (
+	TotalExact    = "exact"
+	TotalEstimate = "estimate"
+	TotalNone     = "none"
+)
+
+// GetActorsResponse, sayfalı liste zarfıdır. Offset modunda Offset, keyset
+// modunda Cursor ve NextCursor/PrevCursor dolar.
 type GetActorsResponse struct {
-	Actors []domain.Actor `json:"actors"`
+	Items []domain.Actor `json:"items"`
+	// Total, Total=none istendiğinde dönülmez.
+	Total          *int64 `json:"total,omitempty"`
+	TotalEstimated bool   `json:"total_estimated,omitempty"`
+	Limit          int    `json:"limit"`
+	Offset         int    `json:"offset"`
+	Cursor         string `json:"cursor,omitempty"`
+	NextCursor     string `json:"next_cursor,omitempty"`
+	PrevCursor     string `json:"prev_cursor,omitempty"`
 }
 
 type GetActorsHandler struct {
 	repository Repository
+	cursors    *Cursors
 }
 
-func NewGetActorsHandler(repository Repository) *GetActorsHandler {
+func NewGetActorsHandler(repository Repository, cursors *Cursors) *GetActorsHandler {
 	return &GetActorsHandler{
 		repository: repository,
+		cursors:    cursors,
 	}
 }
 
+// Handle, limit verilmiş ve offset kullanılmamışsa keyset sayfalama yapar
+// ve sonraki/önceki sayfalar için imleç döner. Offset verilirse eski
+// davranış korunur ve imleç dönülmez.
 func (h *GetActorsHandler) Handle(ctx context.Context, req *GetActorsRequest) (*GetActorsResponse, error) {
+	sort, err := domain.ParseActorSort(req.Sort)
+	if err != nil {
+		return nil, err
+	}
+	if sort == nil && req.OrderBy {
+		sort = []domain.SortField{{Field: domain.SortID, Desc: true}}
+	}
 
-	actors, err := h.repository.GetActors(ctx, req.Search, req.Offset, req.Limit, req.OrderBy)
+	fuzzy := req.SearchMode == SearchFuzzy && req.Search != ""
+	if !fuzzy && slices.ContainsFunc(sort, func(f domain.SortField) bool { return f.Field == domain.SortScore }) {
+		return nil, domain.ErrInvalidSort
+	}
+
+	query := domain.ActorQuery{
+		Search: req.Search,
+		Fuzzy:  fuzzy,
+		Filter: req.Filter,
+		Sort:   sort,
+		Offset: req.Offset,
+		Limit:  req.Limit,
+	}
+
+	keyset := req.Limit > 0 && req.Offset == 0
+	digest := queryDigest(query)
+
+	if req.Cursor != "" {
+		if !keyset {
+			return nil, domain.ErrInvalidCursor
+		}
+		token, err := h.cursors.decode(req.Cursor, digest)
+		if err != nil {
+			return nil, err
+		}
+		if token.Prev {
+			query.Before = token.cursor()
+		} else {
+			query.After = token.cursor()
+		}
+	}
+
+	// Bir fazla kayıt istenerek imleç yönünde başka sayfa olup olmadığı
+	// anlaşılır.
+	if keyset {
+		query.Limit++
+	}
+
+	actors, err := h.repository.GetActors(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	return &GetActorsResponse{
-		Actors: actors,
-	}, nil
+	res := &GetActorsResponse{
+		Limit:  req.Limit,
+		Offset: req.Offset,
+		Cursor: req.Cursor,
+	}
+
+	if req.Total != TotalNone {
+		total, estimated, err := h.repository.CountActors(ctx, query, req.Total == TotalEstimate)
+		if err != nil {
+			return nil, err
+		}
+		res.Total = &total
+		res.TotalEstimated = estimated
+	}
+
+	if !keyset {
+		res.Items = scores(actors, req.WithScore)
+		return res, nil
+	}
+
+	more := len(actors) > req.Limit
+	if more {
+		if query.Before != nil {
+			actors = actors[len(actors)-req.Limit:]
+		} else {
+			actors = actors[:req.Limit]
+		}
+	}
+	if len(actors) == 0 {
+		res.Items = actors
+		return res, nil
+	}
+
+	hasNext := query.Before != nil || more
+	hasPrev := query.After != nil || (query.Before != nil && more)
+	if hasNext {
+		res.NextCursor = h.cursors.encode(newCursorToken(actors[len(actors)-1], false, digest))
+	}
+	if hasPrev {
+		res.PrevCursor = h.cursors.encode(newCursorToken(actors[0], true, digest))
+	}
+	// Puan imleçte kullanıldıktan sonra istenmemişse kaldırılır.
+	res.Items = scores(actors, req.WithScore)
+	return res, nil
+}
+
+func scores(actors []domain.Actor, keep bool) []domain.Actor {
+	if keep {
+		return actors
+	}
+	for i := range actors {
+		actors[i].Score = nil
+	}
+	return actors
 }
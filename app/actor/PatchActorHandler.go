@@ -0,0 +1,143 @@
+package actor
+
+import (
+	"context"
+	"maps"
+	"reflect"
+
+	"github.com/EmreZURNACI/apistack/domain"
+)
+
+type PatchActorRequest struct {
+	ID string `json:"id"`
+	// ContentType, PatchMerge veya PatchJSON'dır.
+	ContentType string `json:"content_type"`
+	Patch       []byte `json:"patch"`
+}
+
+type PatchActorResponse struct {
+	Actor domain.Actor `json:"actor"`
+}
+
+type PatchActorHandler struct {
+	repository Repository
+}
+
+func NewPatchActorHandler(repository Repository) *PatchActorHandler {
+	return &PatchActorHandler{
+		repository: repository,
+	}
+}
+
+// Handle, patch'i aktörün güncel haline uygular ve yalnızca değişen
+// alanları kaydeder. ID ve LastUpdate salt okunurdur; değiştirilmeleri,
+// ad alanlarının silinmesi veya boş bırakılması ErrInvalidPatch döner.
+// Hiçbir alan değişmiyorsa kayıt yazılmadan güncel hali döner.
+func (h *PatchActorHandler) Handle(ctx context.Context, req *PatchActorRequest) (*PatchActorResponse, error) {
+	current, err := h.repository.GetActor(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := documentOf(*current)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := maps.Clone(original)
+
+	var tested []string
+	switch req.ContentType {
+	case PatchMerge:
+		doc, err = mergePatch(doc, req.Patch)
+	case PatchJSON:
+		doc, tested, err = jsonPatch(doc, req.Patch)
+	default:
+		err = domain.ErrInvalidPatch
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := patchOf(original, doc)
+	if err != nil {
+		return nil, err
+	}
+	// Test işlemleri okunan kayda karşı yapıldı; arada başka bir yazma
+	// olduysa güncelleme yapılmamalıdır.
+	patch.If = conditionOf(*current, tested)
+	if patch.Empty() {
+		return &PatchActorResponse{
+			Actor: *current,
+		}, nil
+	}
+
+	actor, err := h.repository.PatchActor(ctx, req.ID, patch)
+	if err != nil {
+		return nil, err
+	}
+	return &PatchActorResponse{
+		Actor: *actor,
+	}, nil
+}
+
+// conditionOf, test edilen alanların okunan kayıttaki değerlerini koşul
+// olarak döner. ID değişmediği için koşula girmez.
+func conditionOf(actor domain.Actor, tested []string) domain.ActorCondition {
+	var c domain.ActorCondition
+	for _, name := range tested {
+		switch name {
+		case "FirstName":
+			c.FirstName = &actor.FirstName
+		case "LastName":
+			c.LastName = &actor.LastName
+		case "LastUpdate":
+			c.LastUpdate = &actor.LastUpdate
+		}
+	}
+	return c
+}
+
+// patchOf, patch uygulanmış dokümanı orijinaliyle karşılaştırıp
+// değişen alanları döner.
+func patchOf(original, patched document) (domain.ActorPatch, error) {
+	var (
+		patch domain.ActorPatch
+		err   error
+	)
+
+	for name, value := range patched {
+		switch name {
+		case "FirstName", "LastName":
+		case "ID", "LastUpdate":
+			if !reflect.DeepEqual(value, original[name]) {
+				return patch, domain.ErrInvalidPatch
+			}
+		default:
+			return patch, domain.ErrInvalidPatch
+		}
+	}
+	for _, name := range []string{"ID", "LastUpdate"} {
+		if _, ok := patched[name]; !ok {
+			return patch, domain.ErrInvalidPatch
+		}
+	}
+
+	name := func(field string) (*string, error) {
+		value, ok := patched[field].(string)
+		if !ok || value == "" {
+			return nil, domain.ErrInvalidPatch
+		}
+		if value == original[field] {
+			return nil, nil
+		}
+		return &value, nil
+	}
+	if patch.FirstName, err = name("FirstName"); err != nil {
+		return patch, err
+	}
+	if patch.LastName, err = name("LastName"); err != nil {
+		return patch, err
+	}
+	return patch, nil
+}
@@ -0,0 +1,114 @@
+package actor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/EmreZURNACI/apistack/domain"
+)
+
+// Cursors, keyset sayfalama imleçlerini istemciye opak token olarak verir.
+// Token HMAC ile imzalandığı için istemci tarafından değiştirilemez; imleç
+// üretildiği sorguya bağlıdır ve başka bir sorguda kullanılamaz. ttl
+// sıfırdan büyükse imleçler bu süre sonunda geçersiz olur.
+type Cursors struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewCursors(secret []byte, ttl time.Duration) *Cursors {
+	return &Cursors{secret: secret, ttl: ttl, now: time.Now}
+}
+
+type cursorToken struct {
+	ID         int64     `json:"id"`
+	FirstName  string    `json:"fn,omitempty"`
+	LastName   string    `json:"ln,omitempty"`
+	LastUpdate time.Time `json:"lu"`
+	Score      float64   `json:"sc,omitempty"`
+	// Prev, imlecin önceki sayfayı gösterdiğini belirtir.
+	Prev bool `json:"prev,omitempty"`
+	// Query, imlecin üretildiği sorgunun özetidir.
+	Query string `json:"q"`
+	// Expires, imlecin geçerli olduğu son andır (Unix saniye).
+	Expires int64 `json:"exp,omitempty"`
+}
+
+func newCursorToken(actor domain.Actor, prev bool, query string) cursorToken {
+	c := domain.CursorOf(actor)
+	return cursorToken{
+		ID:         c.ID,
+		FirstName:  c.FirstName,
+		LastName:   c.LastName,
+		LastUpdate: c.LastUpdate,
+		Score:      c.Score,
+		Prev:       prev,
+		Query:      query,
+	}
+}
+
+func (t cursorToken) cursor() *domain.Cursor {
+	return &domain.Cursor{
+		ID:         t.ID,
+		FirstName:  t.FirstName,
+		LastName:   t.LastName,
+		LastUpdate: t.LastUpdate,
+		Score:      t.Score,
+	}
+}
+
+func (c *Cursors) encode(token cursorToken) string {
+	if c.ttl > 0 {
+		token.Expires = c.now().Add(c.ttl).Unix()
+	}
+	payload, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(c.sign(payload))
+}
+
+func (c *Cursors) decode(value, query string) (cursorToken, error) {
+	var token cursorToken
+
+	encoded, signature, ok := strings.Cut(value, ".")
+	if !ok {
+		return token, domain.ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return token, domain.ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, c.sign(payload)) {
+		return token, domain.ErrInvalidCursor
+	}
+	if err := json.Unmarshal(payload, &token); err != nil || token.Query != query || token.ID <= 0 {
+		return token, domain.ErrInvalidCursor
+	}
+	if c.ttl > 0 && (token.Expires == 0 || c.now().Unix() > token.Expires) {
+		return token, domain.ErrInvalidCursor
+	}
+	return token, nil
+}
+
+func (c *Cursors) sign(payload []byte) []byte {
+	h := hmac.New(sha256.New, c.secret)
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// queryDigest, imlecin bağlı olduğu filtre ve sıralamayı özetler; sayfa
+// boyutu özete girmez, böylece sayfalar arasında değiştirilebilir.
+func queryDigest(query domain.ActorQuery) string {
+	key, _ := json.Marshal(struct {
+		Search string
+		Fuzzy  bool
+		Filter domain.ActorFilter
+		Sort   []domain.SortField
+	}{query.Search, query.Fuzzy, query.Filter, query.SortFields()})
+	sum := sha256.Sum256(key)
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
@@ -0,0 +1,219 @@
+package actor
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EmreZURNACI/apistack/domain"
+	"github.com/EmreZURNACI/apistack/infra/memory"
+	"go.opentelemetry.io/otel"
+)
+
+var testActor = domain.Actor{
+	ID:         42,
+	FirstName:  "Penelope",
+	LastName:   "Guiness",
+	LastUpdate: time.Date(2013, 5, 26, 14, 47, 57, 0, time.UTC),
+}
+
+// clock, imleç süresini test etmek için ileri alınabilen saattir.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func newTestCursors(secret string, ttl time.Duration) (*Cursors, *clock) {
+	c := NewCursors([]byte(secret), ttl)
+	clk := &clock{t: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c.now = clk.now
+	return c, clk
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	for _, prev := range []bool{false, true} {
+		cursors, _ := newTestCursors("secret", time.Hour)
+		value := cursors.encode(newCursorToken(testActor, prev, "q1"))
+
+		token, err := cursors.decode(value, "q1")
+		if err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if token.Prev != prev {
+			t.Fatalf("Prev = %v, want %v", token.Prev, prev)
+		}
+		want := domain.CursorOf(testActor)
+		if got := token.cursor(); got.ID != want.ID || got.LastUpdate != want.LastUpdate {
+			t.Fatalf("cursor = %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestCursorRejected(t *testing.T) {
+	cursors, clk := newTestCursors("secret", time.Hour)
+	value := cursors.encode(newCursorToken(testActor, false, "q1"))
+	payload, signature, _ := strings.Cut(value, ".")
+
+	forged := func(edit func(string) string) string {
+		raw, _ := base64.RawURLEncoding.DecodeString(payload)
+		return base64.RawURLEncoding.EncodeToString([]byte(edit(string(raw)))) + "." + signature
+	}
+	other, _ := newTestCursors("other-secret", time.Hour)
+
+	tests := []struct {
+		name    string
+		cursors *Cursors
+		value   string
+		query   string
+		// after, decode'dan önce saatin ne kadar ileri alınacağıdır.
+		after time.Duration
+	}{
+		{name: "changed id", value: forged(func(s string) string { return strings.Replace(s, `"id":42`, `"id":43`, 1) }), query: "q1"},
+		{name: "changed direction", value: forged(func(s string) string { return strings.Replace(s, `{`, `{"prev":true,`, 1) }), query: "q1"},
+		{name: "changed expiry", value: forged(func(s string) string { return strings.Replace(s, `"exp":`, `"exp":9`, 1) }), query: "q1"},
+		{name: "truncated signature", value: value[:len(value)-2], query: "q1"},
+		{name: "no signature", value: payload, query: "q1"},
+		{name: "not base64", value: "***." + signature, query: "q1"},
+		{name: "empty", value: "", query: "q1"},
+		{name: "other secret", cursors: other, value: value, query: "q1"},
+		{name: "other query", value: value, query: "q2"},
+		{name: "expired", value: value, query: "q1", after: time.Hour + time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cursors
+			if tt.cursors != nil {
+				c = tt.cursors
+			}
+			start := clk.t
+			clk.t = start.Add(tt.after)
+			defer func() { clk.t = start }()
+
+			if _, err := c.decode(tt.value, tt.query); !errors.Is(err, domain.ErrInvalidCursor) {
+				t.Fatalf("decode = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestCursorExpiry(t *testing.T) {
+	cursors, clk := newTestCursors("secret", time.Hour)
+	value := cursors.encode(newCursorToken(testActor, false, "q1"))
+
+	clk.t = clk.t.Add(time.Hour)
+	if _, err := cursors.decode(value, "q1"); err != nil {
+		t.Fatalf("decode at expiry: %v", err)
+	}
+
+	// ttl=0 ile üretilen imleçlerin süresi yoktur; ttl açıldığında kabul
+	// edilmezler.
+	unlimited, _ := newTestCursors("secret", 0)
+	value = unlimited.encode(newCursorToken(testActor, false, "q1"))
+	if _, err := unlimited.decode(value, "q1"); err != nil {
+		t.Fatalf("decode without ttl: %v", err)
+	}
+	if _, err := cursors.decode(value, "q1"); !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Fatalf("decode of a cursor without expiry = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestQueryDigest(t *testing.T) {
+	base := domain.ActorQuery{Search: "pen", Limit: 10}
+	tests := []struct {
+		name  string
+		query domain.ActorQuery
+		same  bool
+	}{
+		{name: "page size", query: domain.ActorQuery{Search: "pen", Limit: 50}, same: true},
+		{name: "search", query: domain.ActorQuery{Search: "nick", Limit: 10}},
+		{name: "fuzzy", query: domain.ActorQuery{Search: "pen", Fuzzy: true, Limit: 10}},
+		{name: "filter", query: domain.ActorQuery{Search: "pen", Filter: domain.ActorFilter{FirstName: "Penelope"}, Limit: 10}},
+		{name: "sort", query: domain.ActorQuery{Search: "pen", Sort: []domain.SortField{{Field: domain.SortLastName}}, Limit: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if same := queryDigest(tt.query) == queryDigest(base); same != tt.same {
+				t.Fatalf("same digest = %v, want %v", same, tt.same)
+			}
+		})
+	}
+}
+
+// TestKeysetPaging, next_cursor ile sona kadar ilerleyip prev_cursor ile
+// başa dönerek tüm kayıtların tekrarsız ve sıralı geldiğini doğrular.
+func TestKeysetPaging(t *testing.T) {
+	repo := memory.GetMemoryHandler(otel.Tracer("test"))
+	ctx := context.Background()
+	var ids []int64
+	for i := range 7 {
+		id, err := repo.CreateActor(ctx, "Actor", fmt.Sprintf("N%d", i))
+		if err != nil {
+			t.Fatalf("CreateActor: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	cursors, _ := newTestCursors("secret", time.Hour)
+	h := NewGetActorsHandler(repo, cursors)
+
+	page := func(cursor string) *GetActorsResponse {
+		t.Helper()
+		res, err := h.Handle(ctx, &GetActorsRequest{Limit: 3, Sort: "id", Cursor: cursor, Total: TotalNone})
+		if err != nil {
+			t.Fatalf("Handle(cursor=%q): %v", cursor, err)
+		}
+		return res
+	}
+	idsOf := func(res *GetActorsResponse) []int64 {
+		var out []int64
+		for _, a := range res.Items {
+			out = append(out, a.ID)
+		}
+		return out
+	}
+
+	// İleri: 3 + 3 + 1.
+	var forward [][]int64
+	res := page("")
+	if res.PrevCursor != "" {
+		t.Fatal("first page has a prev_cursor")
+	}
+	pages := []*GetActorsResponse{res}
+	forward = append(forward, idsOf(res))
+	for res.NextCursor != "" {
+		res = page(res.NextCursor)
+		pages = append(pages, res)
+		forward = append(forward, idsOf(res))
+	}
+	if got := slices.Concat(forward...); !slices.Equal(got, ids) {
+		t.Fatalf("forward pages = %v, want %v", forward, ids)
+	}
+	if len(pages) != 3 || res.PrevCursor == "" {
+		t.Fatalf("got %d pages, last prev_cursor %q; want 3 pages with a prev_cursor", len(pages), res.PrevCursor)
+	}
+
+	// Geri: son sayfadan ilk sayfaya aynı sayfalar ters sırayla gelir.
+	for i := len(pages) - 2; i >= 0; i-- {
+		res = page(res.PrevCursor)
+		if got := idsOf(res); !slices.Equal(got, forward[i]) {
+			t.Fatalf("prev page %d = %v, want %v", i, got, forward[i])
+		}
+		if res.NextCursor == "" {
+			t.Fatalf("prev page %d has no next_cursor", i)
+		}
+	}
+	if res.PrevCursor != "" {
+		t.Fatal("first page reached through prev_cursor still has a prev_cursor")
+	}
+
+	// İmleç başka bir sorguda ve offset ile kullanılamaz.
+	if _, err := h.Handle(ctx, &GetActorsRequest{Limit: 3, Sort: "-id", Cursor: pages[0].NextCursor}); !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Fatalf("cursor with another sort = %v, want ErrInvalidCursor", err)
+	}
+	if _, err := h.Handle(ctx, &GetActorsRequest{Limit: 3, Offset: 3, Sort: "id", Cursor: pages[0].NextCursor}); !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Fatalf("cursor with offset = %v, want ErrInvalidCursor", err)
+	}
+}
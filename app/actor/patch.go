@@ -0,0 +1,170 @@
+package actor
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/EmreZURNACI/apistack/domain"
+)
+
+// Desteklenen patch doküman tipleri.
+const (
+	// PatchMerge, RFC 7396 JSON Merge Patch'tir.
+	PatchMerge = "application/merge-patch+json"
+	// PatchJSON, RFC 6902 JSON Patch'tir.
+	PatchJSON = "application/json-patch+json"
+)
+
+// document, patch'lerin uygulandığı aktör gösterimidir; alan adları
+// API'deki aktör JSON'u ile aynıdır.
+type document map[string]any
+
+func documentOf(actor domain.Actor) (document, error) {
+	raw, err := json.Marshal(actor)
+	if err != nil {
+		return nil, err
+	}
+	var doc document
+	if err := decodeJSON(raw, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// decodeJSON, sayıları float64 yerine json.Number olarak okur; böylece id
+// gibi değerler karşılaştırmada hassasiyet kaybetmez.
+func decodeJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return domain.ErrInvalidPatch
+	}
+	return nil
+}
+
+// mergePatch, RFC 7396'ya göre patch'i dokümana uygular: null değerler
+// alanı siler, nesneler özyinelemeli birleştirilir, diğer değerler alanın
+// yerine geçer.
+func mergePatch(doc document, data []byte) (document, error) {
+	var patch any
+	if err := decodeJSON(data, &patch); err != nil {
+		return nil, domain.ErrInvalidPatch
+	}
+	// Kök nesne değilse doküman tümüyle değişir; aktör için bu her zaman
+	// geçersizdir.
+	obj, ok := patch.(map[string]any)
+	if !ok {
+		return nil, domain.ErrInvalidPatch
+	}
+	return merge(doc, obj), nil
+}
+
+func merge(target, patch map[string]any) map[string]any {
+	if target == nil {
+		target = make(map[string]any, len(patch))
+	}
+	for name, value := range patch {
+		switch v := value.(type) {
+		case nil:
+			delete(target, name)
+		case map[string]any:
+			current, _ := target[name].(map[string]any)
+			target[name] = merge(current, v)
+		default:
+			target[name] = v
+		}
+	}
+	return target
+}
+
+type operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// jsonPatch, RFC 6902 işlemlerini sırayla uygular. Aktör dokümanı düz bir
+// nesne olduğu için yollar yalnızca kök alanları ("/FirstName") gösterebilir.
+// İşlemlerden biri başarısız olursa hiçbiri uygulanmış sayılmaz.
+//
+// tested, patch içinde daha önce değiştirilmeden test edilen alanlardır. Bu
+// testler kaydın okunan haline karşı yapıldığından, yazma sırasında da aynı
+// değerlerin geçerli olması gerekir.
+func jsonPatch(doc document, data []byte) (patched document, tested []string, err error) {
+	var ops []operation
+	if err := decodeJSON(data, &ops); err != nil {
+		return nil, nil, domain.ErrInvalidPatch
+	}
+
+	touched := make(map[string]bool)
+	for _, op := range ops {
+		name, err := member(op.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch op.Op {
+		case "add", "replace", "test":
+			if op.Value == nil {
+				return nil, nil, domain.ErrInvalidPatch
+			}
+			var value any
+			if err := decodeJSON(op.Value, &value); err != nil {
+				return nil, nil, domain.ErrInvalidPatch
+			}
+			current, exists := doc[name]
+			switch {
+			case op.Op == "test" && (!exists || !reflect.DeepEqual(current, value)):
+				return nil, nil, domain.ErrPatchTestFailed
+			case op.Op == "test":
+				if !touched[name] {
+					tested = append(tested, name)
+				}
+			case op.Op == "replace" && !exists:
+				return nil, nil, domain.ErrInvalidPatch
+			default:
+				doc[name] = value
+				touched[name] = true
+			}
+		case "remove":
+			if _, ok := doc[name]; !ok {
+				return nil, nil, domain.ErrInvalidPatch
+			}
+			delete(doc, name)
+			touched[name] = true
+		case "move", "copy":
+			from, err := member(op.From)
+			if err != nil {
+				return nil, nil, err
+			}
+			value, ok := doc[from]
+			if !ok {
+				return nil, nil, domain.ErrInvalidPatch
+			}
+			if op.Op == "move" {
+				delete(doc, from)
+				touched[from] = true
+			}
+			doc[name] = value
+			touched[name] = true
+		default:
+			return nil, nil, domain.ErrInvalidPatch
+		}
+	}
+	return doc, tested, nil
+}
+
+// member, JSON Pointer'ı (RFC 6901) kök alan adına çevirir.
+func member(pointer string) (string, error) {
+	name, ok := strings.CutPrefix(pointer, "/")
+	if !ok || strings.Contains(name, "/") {
+		return "", domain.ErrInvalidPatch
+	}
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(name), nil
+}
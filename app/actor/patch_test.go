@@ -0,0 +1,213 @@
+package actor
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/EmreZURNACI/apistack/domain"
+)
+
+func testDocument(t *testing.T) document {
+	t.Helper()
+	doc, err := documentOf(testActor)
+	if err != nil {
+		t.Fatalf("documentOf: %v", err)
+	}
+	return doc
+}
+
+// with, dokümanın verilen alanları değiştirilmiş kopyasını döner; nil değer
+// alanı siler.
+func with(doc document, fields map[string]any) document {
+	out := maps.Clone(doc)
+	for name, value := range fields {
+		if value == nil {
+			delete(out, name)
+			continue
+		}
+		out[name] = value
+	}
+	return out
+}
+
+func TestMergePatch(t *testing.T) {
+	doc := testDocument(t)
+
+	tests := []struct {
+		name    string
+		patch   string
+		want    document
+		wantErr error
+	}{
+		{name: "replace", patch: `{"FirstName":"Nick"}`, want: with(doc, map[string]any{"FirstName": "Nick"})},
+		{name: "several fields", patch: `{"FirstName":"Nick","LastName":"Wahlberg"}`, want: with(doc, map[string]any{"FirstName": "Nick", "LastName": "Wahlberg"})},
+		{name: "null removes", patch: `{"LastName":null}`, want: with(doc, map[string]any{"LastName": nil})},
+		{name: "null on missing member", patch: `{"Nickname":null}`, want: doc},
+		{name: "add member", patch: `{"Nickname":"Pen"}`, want: with(doc, map[string]any{"Nickname": "Pen"})},
+		{name: "nested object", patch: `{"Meta":{"a":1,"b":null}}`, want: with(doc, map[string]any{"Meta": map[string]any{"a": json.Number("1")}})},
+		{name: "empty", patch: `{}`, want: doc},
+		{name: "array root", patch: `[]`, wantErr: domain.ErrInvalidPatch},
+		{name: "scalar root", patch: `"Nick"`, wantErr: domain.ErrInvalidPatch},
+		{name: "null root", patch: `null`, wantErr: domain.ErrInvalidPatch},
+		{name: "invalid json", patch: `{"FirstName":`, wantErr: domain.ErrInvalidPatch},
+		{name: "trailing data", patch: `{} {}`, wantErr: domain.ErrInvalidPatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergePatch(maps.Clone(doc), []byte(tt.patch))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("mergePatch error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("mergePatch = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	doc := testDocument(t)
+
+	tests := []struct {
+		name       string
+		patch      string
+		want       document
+		wantTested []string
+		wantErr    error
+	}{
+		{
+			name:  "replace",
+			patch: `[{"op":"replace","path":"/FirstName","value":"Nick"}]`,
+			want:  with(doc, map[string]any{"FirstName": "Nick"}),
+		},
+		{
+			name:  "add replaces an existing member",
+			patch: `[{"op":"add","path":"/LastName","value":"Wahlberg"}]`,
+			want:  with(doc, map[string]any{"LastName": "Wahlberg"}),
+		},
+		{
+			name:  "add new member",
+			patch: `[{"op":"add","path":"/Nickname","value":"Pen"}]`,
+			want:  with(doc, map[string]any{"Nickname": "Pen"}),
+		},
+		{
+			name:  "remove",
+			patch: `[{"op":"remove","path":"/LastName"}]`,
+			want:  with(doc, map[string]any{"LastName": nil}),
+		},
+		{
+			// Merge patch'in aksine null değer alanı silmez.
+			name:  "add null value",
+			patch: `[{"op":"add","path":"/LastName","value":null}]`,
+			want:  func() document { d := maps.Clone(doc); d["LastName"] = nil; return d }(),
+		},
+		{
+			name:       "test then replace",
+			patch:      `[{"op":"test","path":"/LastName","value":"Guiness"},{"op":"replace","path":"/FirstName","value":"Nick"}]`,
+			want:       with(doc, map[string]any{"FirstName": "Nick"}),
+			wantTested: []string{"LastName"},
+		},
+		{
+			name:       "test number",
+			patch:      `[{"op":"test","path":"/ID","value":42}]`,
+			want:       doc,
+			wantTested: []string{"ID"},
+		},
+		{
+			name:  "test after own change is not a condition",
+			patch: `[{"op":"replace","path":"/FirstName","value":"Nick"},{"op":"test","path":"/FirstName","value":"Nick"}]`,
+			want:  with(doc, map[string]any{"FirstName": "Nick"}),
+		},
+		{
+			name:  "move",
+			patch: `[{"op":"move","from":"/FirstName","path":"/LastName"}]`,
+			want:  with(doc, map[string]any{"FirstName": nil, "LastName": "Penelope"}),
+		},
+		{
+			name:  "copy",
+			patch: `[{"op":"copy","from":"/FirstName","path":"/LastName"}]`,
+			want:  with(doc, map[string]any{"LastName": "Penelope"}),
+		},
+		{
+			name:  "escaped pointer",
+			patch: `[{"op":"add","path":"/a~1b~0c","value":1}]`,
+			want:  with(doc, map[string]any{"a/b~c": json.Number("1")}),
+		},
+		{name: "empty", patch: `[]`, want: doc},
+
+		{name: "test mismatch", patch: `[{"op":"test","path":"/LastName","value":"Cruz"}]`, wantErr: domain.ErrPatchTestFailed},
+		{name: "test type mismatch", patch: `[{"op":"test","path":"/ID","value":"42"}]`, wantErr: domain.ErrPatchTestFailed},
+		{name: "test missing member", patch: `[{"op":"test","path":"/Nickname","value":"Pen"}]`, wantErr: domain.ErrPatchTestFailed},
+		{name: "replace missing member", patch: `[{"op":"replace","path":"/Nickname","value":"Pen"}]`, wantErr: domain.ErrInvalidPatch},
+		{name: "remove missing member", patch: `[{"op":"remove","path":"/Nickname"}]`, wantErr: domain.ErrInvalidPatch},
+		{name: "missing value", patch: `[{"op":"replace","path":"/FirstName"}]`, wantErr: domain.ErrInvalidPatch},
+		{name: "move from missing member", patch: `[{"op":"move","from":"/Nickname","path":"/FirstName"}]`, wantErr: domain.ErrInvalidPatch},
+		{name: "unknown op", patch: `[{"op":"increment","path":"/ID","value":1}]`, wantErr: domain.ErrInvalidPatch},
+		{name: "pointer without slash", patch: `[{"op":"replace","path":"FirstName","value":"Nick"}]`, wantErr: domain.ErrInvalidPatch},
+		{name: "root pointer", patch: `[{"op":"replace","path":"","value":{}}]`, wantErr: domain.ErrInvalidPatch},
+		{name: "nested pointer", patch: `[{"op":"add","path":"/FirstName/0","value":"N"}]`, wantErr: domain.ErrInvalidPatch},
+		{name: "invalid from pointer", patch: `[{"op":"copy","from":"FirstName","path":"/LastName"}]`, wantErr: domain.ErrInvalidPatch},
+		{name: "not an array", patch: `{"op":"replace","path":"/FirstName","value":"Nick"}`, wantErr: domain.ErrInvalidPatch},
+		{name: "invalid json", patch: `[{"op":`, wantErr: domain.ErrInvalidPatch},
+		// Bir işlem başarısız olursa önceki işlemler de uygulanmaz.
+		{name: "fails atomically", patch: `[{"op":"replace","path":"/FirstName","value":"Nick"},{"op":"test","path":"/LastName","value":"Cruz"}]`, wantErr: domain.ErrPatchTestFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, tested, err := jsonPatch(maps.Clone(doc), []byte(tt.patch))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("jsonPatch error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if got != nil {
+					t.Fatalf("jsonPatch returned a document with error %v", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("jsonPatch = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(tested, tt.wantTested) {
+				t.Fatalf("tested = %v, want %v", tested, tt.wantTested)
+			}
+		})
+	}
+}
+
+func TestPatchOf(t *testing.T) {
+	doc := testDocument(t)
+	nick := "Nick"
+
+	tests := []struct {
+		name    string
+		patched document
+		want    domain.ActorPatch
+		wantErr error
+	}{
+		{name: "first name", patched: with(doc, map[string]any{"FirstName": "Nick"}), want: domain.ActorPatch{FirstName: &nick}},
+		{name: "unchanged", patched: doc},
+		{name: "same id", patched: with(doc, map[string]any{"ID": json.Number("42")})},
+		{name: "removed name", patched: with(doc, map[string]any{"LastName": nil}), wantErr: domain.ErrInvalidPatch},
+		{name: "empty name", patched: with(doc, map[string]any{"LastName": ""}), wantErr: domain.ErrInvalidPatch},
+		{name: "non-string name", patched: with(doc, map[string]any{"LastName": json.Number("1")}), wantErr: domain.ErrInvalidPatch},
+		{name: "changed id", patched: with(doc, map[string]any{"ID": json.Number("43")}), wantErr: domain.ErrInvalidPatch},
+		{name: "removed id", patched: with(doc, map[string]any{"ID": nil}), wantErr: domain.ErrInvalidPatch},
+		{name: "changed last update", patched: with(doc, map[string]any{"LastUpdate": "2020-01-01T00:00:00Z"}), wantErr: domain.ErrInvalidPatch},
+		{name: "unknown member", patched: with(doc, map[string]any{"Nickname": "Pen"}), wantErr: domain.ErrInvalidPatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := patchOf(doc, tt.patched)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("patchOf error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("patchOf = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
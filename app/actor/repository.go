@@ -7,9 +7,18 @@ import (
 )
 
 type Repository interface {
-	GetActors(ctx context.Context, search string, offset, limit int, orderBy bool) ([]domain.Actor, error)
+	// GetActors, eşleşen kayıt yoksa boş liste döner.
+	GetActors(ctx context.Context, query domain.ActorQuery) ([]domain.Actor, error)
+	// CountActors, sayfalamadan bağımsız toplam kayıt sayısını döner.
+	// estimate true ise implementasyon yaklaşık bir sayı dönebilir;
+	// estimated bunu belirtir.
+	CountActors(ctx context.Context, query domain.ActorQuery, estimate bool) (total int64, estimated bool, err error)
 	CreateActor(ctx context.Context, firstName, lastName string) (int64, error)
 	DeleteActor(ctx context.Context, id string) error
 	GetActor(ctx context.Context, id string) (*domain.Actor, error)
 	UpdateActor(ctx context.Context, id, firstname, lastname string) error
+	// PatchActor, yalnızca patch'te verilen alanları günceller ve kaydın
+	// güncel halini döner. patch.If sağlanmıyorsa ErrPatchTestFailed döner;
+	// kontrol ve yazma atomik olmalıdır.
+	PatchActor(ctx context.Context, id string, patch domain.ActorPatch) (*domain.Actor, error)
 }
@@ -0,0 +1,732 @@
+// Package repositorytest, actor.Repository implementasyonlarının aynı
+// davranışı gösterdiğini doğrulayan ortak test setini içerir.
+//
+//	func TestMemoryHandler(t *testing.T) {
+//		repositorytest.Run(t, func(t *testing.T) actor.Repository {
+//			return memory.GetMemoryHandler(otel.Tracer("test"))
+//		})
+//	}
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EmreZURNACI/apistack/app/actor"
+	"github.com/EmreZURNACI/apistack/domain"
+)
+
+var byIDDesc = []domain.SortField{{Field: domain.SortID, Desc: true}}
+
+// Factory, her alt test için boş bir Repository döner. Kalıcı depolar
+// (ör. Postgres) için tabloyu temizlemek factory'nin sorumluluğundadır.
+type Factory func(t *testing.T) actor.Repository
+
+// Run, Repository arayüzünün tüm metodlarını verilen implementasyon
+// üzerinde çalıştırır.
+func Run(t *testing.T, newRepository Factory) {
+	t.Helper()
+
+	t.Run("CreateActor", func(t *testing.T) { testCreateActor(t, newRepository(t)) })
+	t.Run("CreateActorDuplicate", func(t *testing.T) { testCreateActorDuplicate(t, newRepository(t)) })
+	t.Run("CreateActorConcurrent", func(t *testing.T) { testCreateActorConcurrent(t, newRepository(t)) })
+	t.Run("GetActor", func(t *testing.T) { testGetActor(t, newRepository(t)) })
+	t.Run("GetActorMissing", func(t *testing.T) { testGetActorMissing(t, newRepository(t)) })
+	t.Run("GetActorsEmpty", func(t *testing.T) { testGetActorsEmpty(t, newRepository(t)) })
+	t.Run("GetActorsSearch", func(t *testing.T) { testGetActorsSearch(t, newRepository(t)) })
+	t.Run("GetActorsOrderBy", func(t *testing.T) { testGetActorsOrderBy(t, newRepository(t)) })
+	t.Run("GetActorsLimitOffset", func(t *testing.T) { testGetActorsLimitOffset(t, newRepository(t)) })
+	t.Run("GetActorsKeyset", func(t *testing.T) { testGetActorsKeyset(t, newRepository(t)) })
+	t.Run("GetActorsSort", func(t *testing.T) { testGetActorsSort(t, newRepository(t)) })
+	t.Run("GetActorsSortKeyset", func(t *testing.T) { testGetActorsSortKeyset(t, newRepository(t)) })
+	t.Run("GetActorsFilter", func(t *testing.T) { testGetActorsFilter(t, newRepository(t)) })
+	t.Run("GetActorsFuzzy", func(t *testing.T) { testGetActorsFuzzy(t, newRepository(t)) })
+	t.Run("CountActors", func(t *testing.T) { testCountActors(t, newRepository(t)) })
+	t.Run("UpdateActor", func(t *testing.T) { testUpdateActor(t, newRepository(t)) })
+	t.Run("UpdateActorUnchanged", func(t *testing.T) { testUpdateActorUnchanged(t, newRepository(t)) })
+	t.Run("UpdateActorDuplicate", func(t *testing.T) { testUpdateActorDuplicate(t, newRepository(t)) })
+	t.Run("UpdateActorMissing", func(t *testing.T) { testUpdateActorMissing(t, newRepository(t)) })
+	t.Run("PatchActor", func(t *testing.T) { testPatchActor(t, newRepository(t)) })
+	t.Run("PatchActorEmpty", func(t *testing.T) { testPatchActorEmpty(t, newRepository(t)) })
+	t.Run("PatchActorDuplicate", func(t *testing.T) { testPatchActorDuplicate(t, newRepository(t)) })
+	t.Run("PatchActorCondition", func(t *testing.T) { testPatchActorCondition(t, newRepository(t)) })
+	t.Run("PatchActorMissing", func(t *testing.T) { testPatchActorMissing(t, newRepository(t)) })
+	t.Run("DeleteActor", func(t *testing.T) { testDeleteActor(t, newRepository(t)) })
+}
+
+func testCreateActor(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+
+	first := mustCreate(t, repo, "Penelope", "Guiness")
+	second := mustCreate(t, repo, "Nick", "Wahlberg")
+
+	if first <= 0 || second <= 0 {
+		t.Fatalf("CreateActor returned non-positive ids: %d, %d", first, second)
+	}
+	if first == second {
+		t.Fatalf("CreateActor returned the same id twice: %d", first)
+	}
+
+	got, err := repo.GetActor(ctx, strconv.FormatInt(second, 10))
+	if err != nil {
+		t.Fatalf("GetActor(%d): %v", second, err)
+	}
+	if got.FirstName != "Nick" || got.LastName != "Wahlberg" {
+		t.Fatalf("GetActor(%d) = %s %s, want Nick Wahlberg", second, got.FirstName, got.LastName)
+	}
+	if got.LastUpdate.IsZero() {
+		t.Fatalf("GetActor(%d) returned zero LastUpdate", second)
+	}
+}
+
+func testCreateActorDuplicate(t *testing.T, repo actor.Repository) {
+	mustCreate(t, repo, "Penelope", "Guiness")
+
+	_, err := repo.CreateActor(context.Background(), "Penelope", "Guiness")
+	expectError(t, "CreateActor duplicate", err, domain.ErrActorAlreadyExists)
+}
+
+func testCreateActorConcurrent(t *testing.T, repo actor.Repository) {
+	const workers = 100
+
+	var wg sync.WaitGroup
+	ids := make([]int64, workers)
+	errs := make([]error, workers)
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids[i], errs[i] = repo.CreateActor(context.Background(), "Actor", "No"+strconv.Itoa(i))
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[int64]bool, workers)
+	for i := range workers {
+		if errs[i] != nil {
+			t.Fatalf("concurrent CreateActor #%d: %v", i, errs[i])
+		}
+		if seen[ids[i]] {
+			t.Fatalf("concurrent CreateActor returned duplicate id %d", ids[i])
+		}
+		seen[ids[i]] = true
+	}
+}
+
+func testGetActor(t *testing.T, repo actor.Repository) {
+	id := mustCreate(t, repo, "Ed", "Chase")
+
+	got, err := repo.GetActor(context.Background(), strconv.FormatInt(id, 10))
+	if err != nil {
+		t.Fatalf("GetActor(%d): %v", id, err)
+	}
+	if got.ID != id || got.FirstName != "Ed" || got.LastName != "Chase" {
+		t.Fatalf("GetActor(%d) = %+v", id, got)
+	}
+}
+
+func testGetActorMissing(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	id := mustCreate(t, repo, "Ed", "Chase")
+
+	_, err := repo.GetActor(ctx, strconv.FormatInt(id+1000, 10))
+	expectError(t, "GetActor missing", err, domain.ErrActorNotFound)
+
+	_, err = repo.GetActor(ctx, "abc")
+	expectError(t, "GetActor invalid id", err, domain.ErrInvalidActorID)
+}
+
+func testGetActorsEmpty(t *testing.T, repo actor.Repository) {
+	got, err := repo.GetActors(context.Background(), domain.ActorQuery{})
+	expectEmpty(t, "GetActors on empty repository", got, err)
+}
+
+func testGetActorsSearch(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	penelope := mustCreate(t, repo, "Penelope", "Guiness")
+	nick := mustCreate(t, repo, "Nick", "Wahlberg")
+	jennifer := mustCreate(t, repo, "Jennifer", "Davis")
+
+	cases := []struct {
+		search string
+		want   []int64
+	}{
+		{search: "nick", want: []int64{nick}},
+		{search: "NI", want: []int64{nick, jennifer}},
+		{search: "DAV", want: []int64{jennifer}},
+		{search: "e_e", want: []int64{penelope}},
+		{search: "Pe%pe", want: []int64{penelope}},
+		{search: "", want: []int64{penelope, nick, jennifer}},
+	}
+
+	for _, tc := range cases {
+		got, err := repo.GetActors(ctx, domain.ActorQuery{Search: tc.search, Sort: byIDDesc})
+		if err != nil {
+			t.Fatalf("GetActors(search=%q): %v", tc.search, err)
+		}
+		expectIDs(t, "GetActors(search="+strconv.Quote(tc.search)+")", got, sortedDesc(tc.want))
+	}
+
+	got, err := repo.GetActors(ctx, domain.ActorQuery{Search: "zzz"})
+	expectEmpty(t, "GetActors without match", got, err)
+}
+
+func testGetActorsOrderBy(t *testing.T, repo actor.Repository) {
+	ids := []int64{
+		mustCreate(t, repo, "Penelope", "Guiness"),
+		mustCreate(t, repo, "Nick", "Wahlberg"),
+		mustCreate(t, repo, "Ed", "Chase"),
+	}
+
+	got, err := repo.GetActors(context.Background(), domain.ActorQuery{Sort: byIDDesc})
+	if err != nil {
+		t.Fatalf("GetActors(orderBy=true): %v", err)
+	}
+	expectIDs(t, "GetActors(orderBy=true)", got, sortedDesc(ids))
+}
+
+func testGetActorsLimitOffset(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	var ids []int64
+	for i := range 5 {
+		ids = append(ids, mustCreate(t, repo, "Actor", "No"+strconv.Itoa(i)))
+	}
+	desc := sortedDesc(ids)
+
+	got, err := repo.GetActors(ctx, domain.ActorQuery{Limit: 2, Sort: byIDDesc})
+	if err != nil {
+		t.Fatalf("GetActors(limit=2): %v", err)
+	}
+	expectIDs(t, "GetActors(limit=2)", got, desc[:2])
+
+	got, err = repo.GetActors(ctx, domain.ActorQuery{Offset: 2, Limit: 2, Sort: byIDDesc})
+	if err != nil {
+		t.Fatalf("GetActors(offset=2, limit=2): %v", err)
+	}
+	expectIDs(t, "GetActors(offset=2, limit=2)", got, desc[2:4])
+
+	got, err = repo.GetActors(ctx, domain.ActorQuery{Offset: 3, Sort: byIDDesc})
+	if err != nil {
+		t.Fatalf("GetActors(offset=3): %v", err)
+	}
+	expectIDs(t, "GetActors(offset=3)", got, desc[3:])
+
+	got, err = repo.GetActors(ctx, domain.ActorQuery{Offset: 5, Sort: byIDDesc})
+	expectEmpty(t, "GetActors past the end", got, err)
+}
+
+func testGetActorsKeyset(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	var ids []int64
+	for i := range 5 {
+		ids = append(ids, mustCreate(t, repo, "Actor", "No"+strconv.Itoa(i)))
+	}
+	asc := slices.Clone(ids)
+	slices.Sort(asc)
+	desc := sortedDesc(ids)
+
+	cases := []struct {
+		name  string
+		query domain.ActorQuery
+		want  []int64
+	}{
+		{"after", domain.ActorQuery{After: &domain.Cursor{ID: asc[1]}, Limit: 2}, asc[2:4]},
+		{"after desc", domain.ActorQuery{After: &domain.Cursor{ID: desc[1]}, Limit: 2, Sort: byIDDesc}, desc[2:4]},
+		{"before", domain.ActorQuery{Before: &domain.Cursor{ID: asc[4]}, Limit: 2}, asc[2:4]},
+		{"before desc", domain.ActorQuery{Before: &domain.Cursor{ID: desc[4]}, Limit: 2, Sort: byIDDesc}, desc[2:4]},
+		{"before start", domain.ActorQuery{Before: &domain.Cursor{ID: asc[2]}, Limit: 5}, asc[:2]},
+		{"after without limit", domain.ActorQuery{After: &domain.Cursor{ID: asc[2]}}, asc[3:]},
+	}
+
+	for _, tc := range cases {
+		got, err := repo.GetActors(ctx, tc.query)
+		if err != nil {
+			t.Fatalf("GetActors(%s): %v", tc.name, err)
+		}
+		expectIDs(t, "GetActors("+tc.name+")", got, tc.want)
+	}
+
+	got, err := repo.GetActors(ctx, domain.ActorQuery{After: &domain.Cursor{ID: asc[4]}, Limit: 2})
+	expectEmpty(t, "GetActors after the last actor", got, err)
+}
+
+func testGetActorsSort(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	chase := mustCreateLater(t, repo, "Ed", "Chase")
+	davisJ := mustCreateLater(t, repo, "Jennifer", "Davis")
+	davisS := mustCreateLater(t, repo, "Susan", "Davis")
+	guiness := mustCreateLater(t, repo, "Penelope", "Guiness")
+
+	cases := []struct {
+		sort []domain.SortField
+		want []int64
+	}{
+		{[]domain.SortField{{Field: domain.SortLastName}}, []int64{chase, davisJ, davisS, guiness}},
+		{[]domain.SortField{{Field: domain.SortLastName, Desc: true}}, []int64{guiness, davisJ, davisS, chase}},
+		{[]domain.SortField{{Field: domain.SortLastName}, {Field: domain.SortFirstName, Desc: true}}, []int64{chase, davisS, davisJ, guiness}},
+		{[]domain.SortField{{Field: domain.SortFirstName}}, []int64{chase, davisJ, guiness, davisS}},
+		{[]domain.SortField{{Field: domain.SortLastUpdate}}, []int64{chase, davisJ, davisS, guiness}},
+		{[]domain.SortField{{Field: domain.SortLastUpdate, Desc: true}}, []int64{guiness, davisS, davisJ, chase}},
+	}
+	for _, tc := range cases {
+		got, err := repo.GetActors(ctx, domain.ActorQuery{Sort: tc.sort})
+		if err != nil {
+			t.Fatalf("GetActors(sort=%v): %v", tc.sort, err)
+		}
+		expectIDs(t, fmt.Sprintf("GetActors(sort=%v)", tc.sort), got, tc.want)
+	}
+}
+
+// testGetActorsSortKeyset, eşit sıralama değerlerinde de imlecin kayıt
+// atlamadan ve tekrarlamadan ilerlediğini doğrular.
+func testGetActorsSortKeyset(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	names := []string{"Davis", "Chase", "Davis", "Guiness", "Davis", "Chase"}
+	for i, last := range names {
+		mustCreate(t, repo, "Actor"+strconv.Itoa(i), last)
+	}
+	sort := []domain.SortField{{Field: domain.SortLastName, Desc: true}, {Field: domain.SortLastUpdate}}
+
+	all, err := repo.GetActors(ctx, domain.ActorQuery{Sort: sort})
+	if err != nil {
+		t.Fatalf("GetActors(sort): %v", err)
+	}
+	want := make([]int64, len(all))
+	for i, a := range all {
+		want[i] = a.ID
+	}
+
+	var got []domain.Actor
+	var after *domain.Cursor
+	for range len(names) {
+		page, err := repo.GetActors(ctx, domain.ActorQuery{Sort: sort, After: after, Limit: 2})
+		if err != nil {
+			t.Fatalf("GetActors(after=%v): %v", after, err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		got = append(got, page...)
+		cursor := domain.CursorOf(page[len(page)-1])
+		after = &cursor
+	}
+	expectIDs(t, "GetActors paged forward", got, want)
+
+	before := domain.CursorOf(all[4])
+	page, err := repo.GetActors(ctx, domain.ActorQuery{Sort: sort, Before: &before, Limit: 3})
+	if err != nil {
+		t.Fatalf("GetActors(before): %v", err)
+	}
+	expectIDs(t, "GetActors paged backward", page, want[1:4])
+}
+
+func testGetActorsFilter(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	nick := mustCreateLater(t, repo, "Nick", "Wahlberg")
+	nicky := mustCreateLater(t, repo, "Nicky", "Wahl_berg")
+	nickS := mustCreateLater(t, repo, "Nick", "Stallone")
+	ed := mustCreateLater(t, repo, "Ed", "Chase")
+
+	got, err := repo.GetActors(ctx, domain.ActorQuery{})
+	if err != nil {
+		t.Fatalf("GetActors: %v", err)
+	}
+	updated := make(map[int64]domain.Actor, len(got))
+	for _, a := range got {
+		updated[a.ID] = a
+	}
+
+	cases := []struct {
+		name   string
+		filter domain.ActorFilter
+		want   []int64
+	}{
+		{"first_name exact", domain.ActorFilter{FirstName: "Nick"}, []int64{nick, nickS}},
+		{"first_name exact is case sensitive", domain.ActorFilter{FirstName: "nick"}, nil},
+		{"first_name prefix", domain.ActorFilter{FirstNamePrefix: "Nic"}, []int64{nick, nicky, nickS}},
+		{"last_name exact", domain.ActorFilter{LastName: "Chase"}, []int64{ed}},
+		{"last_name prefix", domain.ActorFilter{LastNamePrefix: "Wahl"}, []int64{nick, nicky}},
+		{"last_name prefix escapes wildcards", domain.ActorFilter{LastNamePrefix: "Wahl_"}, []int64{nicky}},
+		{"combined", domain.ActorFilter{FirstName: "Nick", LastNamePrefix: "Wahl"}, []int64{nick}},
+		{"updated_since", domain.ActorFilter{UpdatedSince: updated[nickS].LastUpdate}, []int64{nickS, ed}},
+		{"updated_before", domain.ActorFilter{UpdatedBefore: updated[nickS].LastUpdate}, []int64{nick, nicky}},
+		{"updated range", domain.ActorFilter{UpdatedSince: updated[nicky].LastUpdate, UpdatedBefore: updated[ed].LastUpdate}, []int64{nicky, nickS}},
+	}
+	for _, tc := range cases {
+		got, err := repo.GetActors(ctx, domain.ActorQuery{Filter: tc.filter})
+		if err != nil {
+			t.Fatalf("GetActors(%s): %v", tc.name, err)
+		}
+		expectIDs(t, "GetActors("+tc.name+")", got, tc.want)
+
+		total, _, err := repo.CountActors(ctx, domain.ActorQuery{Filter: tc.filter}, false)
+		if err != nil || total != int64(len(tc.want)) {
+			t.Fatalf("CountActors(%s) = %d, %v; want %d", tc.name, total, err, len(tc.want))
+		}
+	}
+}
+
+func testGetActorsFuzzy(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	sahin := mustCreate(t, repo, "Şükrü", "Şahin")
+	yilmaz := mustCreate(t, repo, "Ayşe", "Yılmaz")
+	ismail := mustCreate(t, repo, "İsmail", "Özdemir")
+	guiness := mustCreate(t, repo, "Penelope", "Guiness")
+	nick := mustCreate(t, repo, "Nick", "Wahlberg")
+	nicky := mustCreate(t, repo, "Nicky", "Wahlberg")
+
+	cases := []struct {
+		name   string
+		search string
+		want   []int64
+	}{
+		{"accents", "sukru sahin", []int64{sahin}},
+		{"dotless i", "YILMAZ", []int64{yilmaz}},
+		{"dotted capital i", "ismail", []int64{ismail}},
+		{"typo", "Guines", []int64{guiness}},
+		{"ranked by score", "nick", []int64{nick, nicky}},
+		{"no match", "Chase", nil},
+	}
+	for _, tc := range cases {
+		query := domain.ActorQuery{Search: tc.search, Fuzzy: true}
+		got, err := repo.GetActors(ctx, query)
+		if err != nil {
+			t.Fatalf("GetActors(%s): %v", tc.name, err)
+		}
+		expectIDs(t, "GetActors("+tc.name+")", got, tc.want)
+		for i, a := range got {
+			if a.Score == nil || *a.Score <= 0 || *a.Score > 1 {
+				t.Fatalf("GetActors(%s) score = %v; want in (0, 1]", tc.name, a.Score)
+			}
+			if i > 0 && *a.Score > *got[i-1].Score {
+				t.Fatalf("GetActors(%s) not ranked by score: %v > %v", tc.name, *a.Score, *got[i-1].Score)
+			}
+		}
+
+		total, _, err := repo.CountActors(ctx, query, false)
+		if err != nil || total != int64(len(tc.want)) {
+			t.Fatalf("CountActors(%s) = %d, %v; want %d", tc.name, total, err, len(tc.want))
+		}
+	}
+
+	// Puana göre sıralamada imleç bir sonraki kayda geçer.
+	query := domain.ActorQuery{Search: "nick", Fuzzy: true, Limit: 1}
+	first, err := repo.GetActors(ctx, query)
+	if err != nil || len(first) != 1 {
+		t.Fatalf("GetActors(fuzzy limit) = %v, %v", first, err)
+	}
+	cursor := domain.CursorOf(first[0])
+	query.After = &cursor
+	next, err := repo.GetActors(ctx, query)
+	if err != nil {
+		t.Fatalf("GetActors(fuzzy after): %v", err)
+	}
+	expectIDs(t, "GetActors(fuzzy after)", next, []int64{nicky})
+
+	// Aramasız sorgularda puan dönmez.
+	all, err := repo.GetActors(ctx, domain.ActorQuery{Search: "nick"})
+	if err != nil {
+		t.Fatalf("GetActors: %v", err)
+	}
+	for _, a := range all {
+		if a.Score != nil {
+			t.Fatalf("GetActors(contains) score = %v; want nil", *a.Score)
+		}
+	}
+}
+
+func testCountActors(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+
+	total, _, err := repo.CountActors(ctx, domain.ActorQuery{}, false)
+	if err != nil || total != 0 {
+		t.Fatalf("CountActors on empty repository = %d, %v; want 0, nil", total, err)
+	}
+
+	mustCreate(t, repo, "Penelope", "Guiness")
+	mustCreate(t, repo, "Nick", "Wahlberg")
+	nick := mustCreate(t, repo, "Nick", "Stallone")
+
+	cases := []struct {
+		query domain.ActorQuery
+		want  int64
+	}{
+		{domain.ActorQuery{}, 3},
+		{domain.ActorQuery{Search: "nick"}, 2},
+		{domain.ActorQuery{Search: "nick", Limit: 1, Offset: 1}, 2},
+		{domain.ActorQuery{Search: "nick", After: &domain.Cursor{ID: nick}, Limit: 1}, 2},
+		{domain.ActorQuery{Search: "zzz"}, 0},
+	}
+	for _, tc := range cases {
+		total, estimated, err := repo.CountActors(ctx, tc.query, false)
+		if err != nil {
+			t.Fatalf("CountActors(%+v): %v", tc.query, err)
+		}
+		if estimated || total != tc.want {
+			t.Fatalf("CountActors(%+v) = %d (estimated=%t), want exact %d", tc.query, total, estimated, tc.want)
+		}
+	}
+
+	// Tahmin istendiğinde implementasyon kesin sayı da dönebilir; yalnızca
+	// kesin olduğunu söylediği sayı doğrulanır.
+	total, estimated, err := repo.CountActors(ctx, domain.ActorQuery{}, true)
+	if err != nil {
+		t.Fatalf("CountActors(estimate): %v", err)
+	}
+	if !estimated && total != 3 {
+		t.Fatalf("CountActors(estimate) = %d exact, want 3", total)
+	}
+
+	// Filtreli sorgularda tahmin tablonun tamamını gösterir; sayı kesin
+	// olmalıdır.
+	filtered := domain.ActorQuery{Filter: domain.ActorFilter{LastName: "Stallone"}}
+	total, estimated, err = repo.CountActors(ctx, filtered, true)
+	if err != nil || estimated || total != 1 {
+		t.Fatalf("CountActors(estimate, filter) = %d (estimated=%t), %v; want exact 1", total, estimated, err)
+	}
+}
+
+func testUpdateActor(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	id := strconv.FormatInt(mustCreate(t, repo, "Penelope", "Guiness"), 10)
+
+	if err := repo.UpdateActor(ctx, id, "Penelope", "Cruz"); err != nil {
+		t.Fatalf("UpdateActor(%s): %v", id, err)
+	}
+
+	got, err := repo.GetActor(ctx, id)
+	if err != nil {
+		t.Fatalf("GetActor(%s): %v", id, err)
+	}
+	if got.FirstName != "Penelope" || got.LastName != "Cruz" {
+		t.Fatalf("GetActor(%s) after update = %s %s, want Penelope Cruz", id, got.FirstName, got.LastName)
+	}
+}
+
+func testUpdateActorUnchanged(t *testing.T, repo actor.Repository) {
+	id := strconv.FormatInt(mustCreate(t, repo, "Penelope", "Guiness"), 10)
+
+	err := repo.UpdateActor(context.Background(), id, "Penelope", "Guiness")
+	expectError(t, "UpdateActor with same values", err, domain.ErrActorUnchanged)
+}
+
+func testUpdateActorDuplicate(t *testing.T, repo actor.Repository) {
+	mustCreate(t, repo, "Penelope", "Guiness")
+	id := strconv.FormatInt(mustCreate(t, repo, "Nick", "Wahlberg"), 10)
+
+	err := repo.UpdateActor(context.Background(), id, "Penelope", "Guiness")
+	expectError(t, "UpdateActor to an existing actor", err, domain.ErrActorAlreadyExists)
+}
+
+func testUpdateActorMissing(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	id := mustCreate(t, repo, "Penelope", "Guiness")
+
+	err := repo.UpdateActor(ctx, strconv.FormatInt(id+1000, 10), "Nick", "Wahlberg")
+	expectError(t, "UpdateActor missing", err, domain.ErrActorNotFound)
+
+	err = repo.UpdateActor(ctx, "abc", "Nick", "Wahlberg")
+	expectError(t, "UpdateActor invalid id", err, domain.ErrInvalidActorID)
+}
+
+func testPatchActor(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	id := strconv.FormatInt(mustCreate(t, repo, "Penelope", "Guiness"), 10)
+
+	before, err := repo.GetActor(ctx, id)
+	if err != nil {
+		t.Fatalf("GetActor(%s): %v", id, err)
+	}
+
+	lastName := "Cruz"
+	got, err := repo.PatchActor(ctx, id, domain.ActorPatch{LastName: &lastName})
+	if err != nil {
+		t.Fatalf("PatchActor(%s): %v", id, err)
+	}
+	if got.ID != before.ID || got.FirstName != "Penelope" || got.LastName != "Cruz" {
+		t.Fatalf("PatchActor(%s) = %d %s %s, want %d Penelope Cruz", id, got.ID, got.FirstName, got.LastName, before.ID)
+	}
+	if got.LastUpdate.Before(before.LastUpdate) {
+		t.Fatalf("PatchActor(%s) LastUpdate = %v, before %v", id, got.LastUpdate, before.LastUpdate)
+	}
+
+	stored, err := repo.GetActor(ctx, id)
+	if err != nil {
+		t.Fatalf("GetActor(%s): %v", id, err)
+	}
+	if stored.FirstName != got.FirstName || stored.LastName != got.LastName || !stored.LastUpdate.Equal(got.LastUpdate) {
+		t.Fatalf("GetActor(%s) after patch = %+v, want %+v", id, stored, got)
+	}
+}
+
+func testPatchActorEmpty(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	id := strconv.FormatInt(mustCreate(t, repo, "Penelope", "Guiness"), 10)
+
+	got, err := repo.PatchActor(ctx, id, domain.ActorPatch{})
+	if err != nil {
+		t.Fatalf("PatchActor(%s) empty: %v", id, err)
+	}
+	if got.FirstName != "Penelope" || got.LastName != "Guiness" {
+		t.Fatalf("PatchActor(%s) empty = %s %s, want Penelope Guiness", id, got.FirstName, got.LastName)
+	}
+}
+
+func testPatchActorDuplicate(t *testing.T, repo actor.Repository) {
+	mustCreate(t, repo, "Penelope", "Guiness")
+	id := strconv.FormatInt(mustCreate(t, repo, "Penelope", "Cruz"), 10)
+
+	lastName := "Guiness"
+	_, err := repo.PatchActor(context.Background(), id, domain.ActorPatch{LastName: &lastName})
+	expectError(t, "PatchActor to an existing actor", err, domain.ErrActorAlreadyExists)
+}
+
+func testPatchActorCondition(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	id := strconv.FormatInt(mustCreate(t, repo, "Penelope", "Guiness"), 10)
+
+	read, err := repo.GetActor(ctx, id)
+	if err != nil {
+		t.Fatalf("GetActor(%s): %v", id, err)
+	}
+
+	// Okumadan sonra yapılan yazma koşulu bozar.
+	time.Sleep(2 * time.Millisecond)
+	if err := repo.UpdateActor(ctx, id, "Penelope", "Cruz"); err != nil {
+		t.Fatalf("UpdateActor(%s): %v", id, err)
+	}
+
+	firstName := "Nick"
+	stale := []domain.ActorCondition{
+		{LastName: &read.LastName},
+		{LastUpdate: &read.LastUpdate},
+	}
+	for _, cond := range stale {
+		_, err := repo.PatchActor(ctx, id, domain.ActorPatch{FirstName: &firstName, If: cond})
+		expectError(t, "PatchActor with stale condition", err, domain.ErrPatchTestFailed)
+
+		_, err = repo.PatchActor(ctx, id, domain.ActorPatch{If: cond})
+		expectError(t, "PatchActor empty with stale condition", err, domain.ErrPatchTestFailed)
+	}
+
+	got, err := repo.GetActor(ctx, id)
+	if err != nil {
+		t.Fatalf("GetActor(%s): %v", id, err)
+	}
+	if got.FirstName != "Penelope" || got.LastName != "Cruz" {
+		t.Fatalf("GetActor(%s) after failed patch = %s %s, want Penelope Cruz", id, got.FirstName, got.LastName)
+	}
+
+	patched, err := repo.PatchActor(ctx, id, domain.ActorPatch{
+		FirstName: &firstName,
+		If:        domain.ActorCondition{LastName: &got.LastName, LastUpdate: &got.LastUpdate},
+	})
+	if err != nil {
+		t.Fatalf("PatchActor with current condition: %v", err)
+	}
+	if patched.FirstName != "Nick" || patched.LastName != "Cruz" {
+		t.Fatalf("PatchActor with current condition = %s %s, want Nick Cruz", patched.FirstName, patched.LastName)
+	}
+
+	missing := strconv.FormatInt(read.ID+1000, 10)
+	_, err = repo.PatchActor(ctx, missing, domain.ActorPatch{FirstName: &firstName, If: domain.ActorCondition{LastName: &got.LastName}})
+	expectError(t, "PatchActor missing with condition", err, domain.ErrActorNotFound)
+}
+
+func testPatchActorMissing(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	id := mustCreate(t, repo, "Penelope", "Guiness")
+
+	firstName := "Nick"
+	_, err := repo.PatchActor(ctx, strconv.FormatInt(id+1000, 10), domain.ActorPatch{FirstName: &firstName})
+	expectError(t, "PatchActor missing", err, domain.ErrActorNotFound)
+
+	_, err = repo.PatchActor(ctx, "abc", domain.ActorPatch{FirstName: &firstName})
+	expectError(t, "PatchActor invalid id", err, domain.ErrInvalidActorID)
+}
+
+func testDeleteActor(t *testing.T, repo actor.Repository) {
+	ctx := context.Background()
+	id := strconv.FormatInt(mustCreate(t, repo, "Penelope", "Guiness"), 10)
+	other := mustCreate(t, repo, "Nick", "Wahlberg")
+
+	if err := repo.DeleteActor(ctx, id); err != nil {
+		t.Fatalf("DeleteActor(%s): %v", id, err)
+	}
+
+	_, err := repo.GetActor(ctx, id)
+	expectError(t, "GetActor after delete", err, domain.ErrActorNotFound)
+
+	err = repo.DeleteActor(ctx, id)
+	expectError(t, "DeleteActor twice", err, domain.ErrActorNotFound)
+
+	got, err := repo.GetActors(ctx, domain.ActorQuery{Sort: byIDDesc})
+	if err != nil {
+		t.Fatalf("GetActors after delete: %v", err)
+	}
+	expectIDs(t, "GetActors after delete", got, []int64{other})
+}
+
+func mustCreate(t *testing.T, repo actor.Repository, firstName, lastName string) int64 {
+	t.Helper()
+
+	id, err := repo.CreateActor(context.Background(), firstName, lastName)
+	if err != nil {
+		t.Fatalf("CreateActor(%s, %s): %v", firstName, lastName, err)
+	}
+	return id
+}
+
+func expectError(t *testing.T, op string, got, want error) {
+	t.Helper()
+
+	if !errors.Is(got, want) {
+		t.Fatalf("%s: got error %v, want %v", op, got, want)
+	}
+}
+
+// mustCreateLater, kaydı bir öncekinden farklı bir LastUpdate ile
+// oluşturur; LastUpdate'e göre sıralama ve filtre testleri eşitliğe
+// düşmez.
+func mustCreateLater(t *testing.T, repo actor.Repository, firstName, lastName string) int64 {
+	t.Helper()
+	time.Sleep(2 * time.Millisecond)
+	return mustCreate(t, repo, firstName, lastName)
+}
+
+func expectEmpty(t *testing.T, op string, got []domain.Actor, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("%s: unexpected error %v", op, err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Fatalf("%s: got %v, want an empty non-nil slice", op, got)
+	}
+}
+
+func expectIDs(t *testing.T, op string, got []domain.Actor, want []int64) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d actors, want %d", op, len(got), len(want))
+	}
+	for i := range want {
+		if got[i].ID != want[i] {
+			t.Fatalf("%s: actor[%d].ID = %d, want %d", op, i, got[i].ID, want[i])
+		}
+	}
+}
+
+func sortedDesc(ids []int64) []int64 {
+	out := slices.Clone(ids)
+	slices.Sort(out)
+	slices.Reverse(out)
+	return out
+}
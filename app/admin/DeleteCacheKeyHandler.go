@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/EmreZURNACI/apistack/cache"
+	"github.com/EmreZURNACI/apistack/domain"
+	"github.com/EmreZURNACI/apistack/i18n"
+)
+
+type DeleteCacheKeyRequest struct {
+	Key string `json:"key"`
+}
+type DeleteCacheKeyResponse struct {
+	Message string `json:"message"`
+	Deleted int    `json:"deleted"`
+}
+
+type DeleteCacheKeyHandler struct {
+	keys  KeyStore
+	cache cache.Cache
+}
+
+func NewDeleteCacheKeyHandler(keys KeyStore, cache cache.Cache) *DeleteCacheKeyHandler {
+	return &DeleteCacheKeyHandler{
+		keys:  keys,
+		cache: cache,
+	}
+}
+
+func (h *DeleteCacheKeyHandler) Handle(ctx context.Context, req *DeleteCacheKeyRequest) (*DeleteCacheKeyResponse, error) {
+	if !h.keys.Healthy(ctx) {
+		return nil, domain.ErrCacheUnavailable
+	}
+
+	ttls, err := h.keys.TTLs(ctx, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	if ttls[0] == ttlMissing {
+		return nil, domain.ErrCacheKeyNotFound
+	}
+
+	if err := h.cache.Delete(ctx, req.Key); err != nil {
+		return nil, err
+	}
+	return &DeleteCacheKeyResponse{
+		Message: i18n.T(ctx, "cache_key_deleted"),
+		Deleted: 1,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/EmreZURNACI/apistack/cache"
+	"github.com/EmreZURNACI/apistack/domain"
+	"github.com/EmreZURNACI/apistack/i18n"
+)
+
+type FlushCacheRequest struct {
+	Prefix string `json:"prefix"`
+}
+type FlushCacheResponse struct {
+	Message string `json:"message"`
+	Deleted int    `json:"deleted"`
+}
+
+type FlushCacheHandler struct {
+	keys  KeyStore
+	cache cache.Cache
+}
+
+func NewFlushCacheHandler(keys KeyStore, cache cache.Cache) *FlushCacheHandler {
+	return &FlushCacheHandler{
+		keys:  keys,
+		cache: cache,
+	}
+}
+
+// Handle, prefix ile başlayan anahtarları tarama sırasında parça parça
+// siler; tüm anahtarlar hiçbir zaman tek seferde belleğe alınmaz.
+func (h *FlushCacheHandler) Handle(ctx context.Context, req *FlushCacheRequest) (*FlushCacheResponse, error) {
+	if !h.keys.Healthy(ctx) {
+		return nil, domain.ErrCacheUnavailable
+	}
+
+	deleted := 0
+	err := h.keys.ScanKeys(ctx, req.Prefix, func(keys []string) error {
+		if err := h.cache.Delete(ctx, keys...); err != nil {
+			return err
+		}
+		deleted += len(keys)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &FlushCacheResponse{
+		Message: i18n.T(ctx, "cache_flushed"),
+		Deleted: deleted,
+	}, nil
+}
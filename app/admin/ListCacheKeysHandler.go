@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EmreZURNACI/apistack/domain"
+)
+
+const (
+	DefaultKeyLimit = 100
+	MaxKeyLimit     = 1000
+)
+
+// errLimitReached, taramayı limit dolduğunda durdurmak için kullanılır.
+var errLimitReached = errors.New("limit reached")
+
+type ListCacheKeysRequest struct {
+	Prefix string `json:"prefix"`
+	Limit  int    `json:"limit"`
+}
+type CacheKey struct {
+	Key string `json:"key"`
+	// TTL milisaniyedir; süresiz anahtarlar için -1'dir.
+	TTL int64 `json:"ttl_ms"`
+}
+type ListCacheKeysResponse struct {
+	Keys []CacheKey `json:"keys"`
+	// Truncated, limitten fazla anahtar olduğunu belirtir.
+	Truncated bool `json:"truncated"`
+}
+
+type ListCacheKeysHandler struct {
+	keys KeyStore
+}
+
+func NewListCacheKeysHandler(keys KeyStore) *ListCacheKeysHandler {
+	return &ListCacheKeysHandler{
+		keys: keys,
+	}
+}
+
+func (h *ListCacheKeysHandler) Handle(ctx context.Context, req *ListCacheKeysRequest) (*ListCacheKeysResponse, error) {
+	if !h.keys.Healthy(ctx) {
+		return nil, domain.ErrCacheUnavailable
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultKeyLimit
+	}
+	limit = min(limit, MaxKeyLimit)
+
+	res := &ListCacheKeysResponse{Keys: []CacheKey{}}
+	err := h.keys.ScanKeys(ctx, req.Prefix, func(keys []string) error {
+		if remaining := limit - len(res.Keys); len(keys) > remaining {
+			keys = keys[:remaining]
+			res.Truncated = true
+		}
+
+		ttls, err := h.keys.TTLs(ctx, keys...)
+		if err != nil {
+			return err
+		}
+		for i, key := range keys {
+			// Tarama ile TTL okuması arasında süresi dolan anahtarlar atlanır.
+			if ttls[i] == ttlMissing {
+				continue
+			}
+			ttl := int64(-1)
+			if ttls[i] != ttlPersistent {
+				ttl = ttls[i].Milliseconds()
+			}
+			res.Keys = append(res.Keys, CacheKey{Key: key, TTL: ttl})
+		}
+
+		if res.Truncated {
+			return errLimitReached
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errLimitReached) {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,197 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EmreZURNACI/apistack/cache"
+	"github.com/EmreZURNACI/apistack/domain"
+	"github.com/EmreZURNACI/apistack/i18n"
+)
+
+// fakeStore, hem KeyStore hem cache.Cache olarak kullanılan süreç içi
+// anahtar deposudur. ScanKeys anahtarları batch'lik parçalarla döner.
+type fakeStore struct {
+	ttls      map[string]time.Duration
+	unhealthy bool
+	batch     int
+}
+
+func newFakeStore(ttls map[string]time.Duration) *fakeStore {
+	return &fakeStore{ttls: ttls, batch: 2}
+}
+
+func (s *fakeStore) Healthy(context.Context) bool { return !s.unhealthy }
+
+func (s *fakeStore) ScanKeys(_ context.Context, prefix string, fn func(keys []string) error) error {
+	var keys []string
+	for key := range s.ttls {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for chunk := range slices.Chunk(keys, s.batch) {
+		if err := fn(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeStore) TTLs(_ context.Context, keys ...string) ([]time.Duration, error) {
+	ttls := make([]time.Duration, len(keys))
+	for i, key := range keys {
+		ttl, ok := s.ttls[key]
+		if !ok {
+			ttl = ttlMissing
+		}
+		ttls[i] = ttl
+	}
+	return ttls, nil
+}
+
+func (s *fakeStore) Get(context.Context, string) ([]byte, error) { return nil, cache.ErrMiss }
+
+func (s *fakeStore) Set(_ context.Context, key string, _ []byte, ttl time.Duration) error {
+	s.ttls[key] = ttl
+	return nil
+}
+
+func (s *fakeStore) Delete(_ context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(s.ttls, key)
+	}
+	return nil
+}
+
+func (s *fakeStore) Incr(context.Context, string) (int64, error) { return 0, nil }
+
+func keys(n int, prefix string) map[string]time.Duration {
+	ttls := make(map[string]time.Duration, n)
+	for i := range n {
+		ttls[fmt.Sprintf("%s%03d", prefix, i)] = time.Minute
+	}
+	return ttls
+}
+
+func TestListCacheKeys(t *testing.T) {
+	tests := []struct {
+		name          string
+		ttls          map[string]time.Duration
+		req           ListCacheKeysRequest
+		want          []CacheKey
+		wantTruncated bool
+	}{
+		{
+			name: "prefix and ttl",
+			ttls: map[string]time.Duration{
+				"v1:actor:1":  1500 * time.Millisecond,
+				"v1:actor:2":  ttlPersistent,
+				"v1:actors:x": time.Minute,
+			},
+			req: ListCacheKeysRequest{Prefix: "v1:actor:"},
+			want: []CacheKey{
+				{Key: "v1:actor:1", TTL: 1500},
+				{Key: "v1:actor:2", TTL: -1},
+			},
+		},
+		{
+			name:          "limit",
+			ttls:          keys(5, "k"),
+			req:           ListCacheKeysRequest{Limit: 3},
+			want:          []CacheKey{{Key: "k000", TTL: 60000}, {Key: "k001", TTL: 60000}, {Key: "k002", TTL: 60000}},
+			wantTruncated: true,
+		},
+		{
+			name: "no keys",
+			ttls: map[string]time.Duration{"other": time.Minute},
+			req:  ListCacheKeysRequest{Prefix: "v1:"},
+			want: []CacheKey{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := NewListCacheKeysHandler(newFakeStore(tt.ttls)).Handle(context.Background(), &tt.req)
+			if err != nil {
+				t.Fatalf("Handle: %v", err)
+			}
+			if !slices.Equal(res.Keys, tt.want) || res.Truncated != tt.wantTruncated {
+				t.Fatalf("Handle = %v truncated=%v, want %v truncated=%v", res.Keys, res.Truncated, tt.want, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestListCacheKeysDefaultLimit(t *testing.T) {
+	res, err := NewListCacheKeysHandler(newFakeStore(keys(DefaultKeyLimit+1, "k"))).Handle(context.Background(), &ListCacheKeysRequest{})
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if len(res.Keys) != DefaultKeyLimit || !res.Truncated {
+		t.Fatalf("Handle returned %d keys, truncated=%v; want %d, true", len(res.Keys), res.Truncated, DefaultKeyLimit)
+	}
+}
+
+func TestFlushCache(t *testing.T) {
+	ttls := keys(5, "v1:actors:")
+	ttls["v1:actor:1"] = time.Minute
+	store := newFakeStore(ttls)
+
+	ctx := i18n.WithLanguage(context.Background(), i18n.English)
+	res, err := NewFlushCacheHandler(store, store).Handle(ctx, &FlushCacheRequest{Prefix: "v1:actors:"})
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if res.Deleted != 5 || res.Message != "Cache keys deleted" {
+		t.Fatalf("Handle = %+v, want 5 deleted", res)
+	}
+	if _, ok := store.ttls["v1:actor:1"]; !ok || len(store.ttls) != 1 {
+		t.Fatalf("keys left after flush = %v, want only v1:actor:1", store.ttls)
+	}
+}
+
+func TestDeleteCacheKey(t *testing.T) {
+	store := newFakeStore(map[string]time.Duration{"v1:actor:1": time.Minute, "v1:actor:2": time.Minute})
+	handler := NewDeleteCacheKeyHandler(store, store)
+
+	ctx := i18n.WithLanguage(context.Background(), i18n.English)
+	res, err := handler.Handle(ctx, &DeleteCacheKeyRequest{Key: "v1:actor:1"})
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if res.Deleted != 1 || res.Message != "Cache key deleted" {
+		t.Fatalf("Handle = %+v, want 1 deleted with the cache_key_deleted message", res)
+	}
+	if _, ok := store.ttls["v1:actor:1"]; ok {
+		t.Fatal("key still present after delete")
+	}
+
+	if _, err := handler.Handle(ctx, &DeleteCacheKeyRequest{Key: "v1:actor:1"}); !errors.Is(err, domain.ErrCacheKeyNotFound) {
+		t.Fatalf("Handle on a missing key = %v, want ErrCacheKeyNotFound", err)
+	}
+}
+
+func TestCacheUnavailable(t *testing.T) {
+	store := newFakeStore(keys(1, "k"))
+	store.unhealthy = true
+	ctx := context.Background()
+
+	if _, err := NewListCacheKeysHandler(store).Handle(ctx, &ListCacheKeysRequest{}); !errors.Is(err, domain.ErrCacheUnavailable) {
+		t.Errorf("ListCacheKeys = %v, want ErrCacheUnavailable", err)
+	}
+	if _, err := NewFlushCacheHandler(store, store).Handle(ctx, &FlushCacheRequest{Prefix: "k"}); !errors.Is(err, domain.ErrCacheUnavailable) {
+		t.Errorf("FlushCache = %v, want ErrCacheUnavailable", err)
+	}
+	if _, err := NewDeleteCacheKeyHandler(store, store).Handle(ctx, &DeleteCacheKeyRequest{Key: "k000"}); !errors.Is(err, domain.ErrCacheUnavailable) {
+		t.Errorf("DeleteCacheKey = %v, want ErrCacheUnavailable", err)
+	}
+	if len(store.ttls) != 1 {
+		t.Error("keys deleted while the cache was unavailable")
+	}
+}
@@ -0,0 +1,22 @@
+package admin
+
+import (
+	"context"
+	"time"
+)
+
+// KeyStore, anahtarları taranabilen cache'tir (ör. Redis). Silme işlemleri
+// cache.Cache üzerinden yapılır; böylece yerel cache katmanı olan diğer
+// replikalar da bilgilendirilir.
+type KeyStore interface {
+	Healthy(ctx context.Context) bool
+	ScanKeys(ctx context.Context, prefix string, fn func(keys []string) error) error
+	// TTLs, süresiz anahtarlar için ttlPersistent, bulunmayanlar için
+	// ttlMissing döner.
+	TTLs(ctx context.Context, keys ...string) ([]time.Duration, error)
+}
+
+const (
+	ttlPersistent time.Duration = -1
+	ttlMissing    time.Duration = -2
+)
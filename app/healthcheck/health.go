@@ -2,18 +2,46 @@ package healthcheck
 
 import "context"
 
+const (
+	StatusOK       = "OK"
+	StatusDegraded = "DEGRADED"
+)
+
+// Checker, sağlığı raporlanan bir bağımlılıktır (ör. Redis).
+type Checker interface {
+	Healthy(ctx context.Context) bool
+}
+
 type HealthCheckRequest struct {
 }
 type HealthCheckResponse struct {
-	Message string `json:"message"`
+	Message    string            `json:"message"`
+	Components map[string]string `json:"components,omitempty"`
 }
 type HealthCheckHandler struct {
+	checkers map[string]Checker
 }
 
-func NewHealthCheckHandler() *HealthCheckHandler {
-	return &HealthCheckHandler{}
+func NewHealthCheckHandler(checkers map[string]Checker) *HealthCheckHandler {
+	return &HealthCheckHandler{checkers: checkers}
 }
 
+// Handle, opsiyonel bir bağımlılık çalışmıyorsa servis istek almaya devam
+// ettiği için DEGRADED döner.
 func (h *HealthCheckHandler) Handle(ctx context.Context, req *HealthCheckRequest) (*HealthCheckResponse, error) {
-	return &HealthCheckResponse{Message: "OK"}, nil
+	res := &HealthCheckResponse{Message: StatusOK}
+	if len(h.checkers) == 0 {
+		return res, nil
+	}
+
+	res.Components = make(map[string]string, len(h.checkers))
+	for name, checker := range h.checkers {
+		if checker.Healthy(ctx) {
+			res.Components[name] = "up"
+			continue
+		}
+		res.Components[name] = "down"
+		res.Message = StatusDegraded
+	}
+	return res, nil
 }
@@ -0,0 +1,45 @@
+package healthcheck
+
+import "context"
+
+const (
+	StatusReady    = "READY"
+	StatusNotReady = "NOT_READY"
+)
+
+type ReadinessRequest struct {
+}
+type ReadinessResponse struct {
+	Message    string            `json:"message"`
+	Ready      bool              `json:"-"`
+	Components map[string]string `json:"components,omitempty"`
+}
+type ReadinessHandler struct {
+	checkers map[string]Checker
+}
+
+func NewReadinessHandler(checkers map[string]Checker) *ReadinessHandler {
+	return &ReadinessHandler{checkers: checkers}
+}
+
+// Handle, başlangıç işlerinden (ör. cache warm-up) biri bitmemişse
+// NOT_READY döner. Healthcheck'ten farklı olarak opsiyonel bağımlılıklar
+// burada kontrol edilmez.
+func (h *ReadinessHandler) Handle(ctx context.Context, req *ReadinessRequest) (*ReadinessResponse, error) {
+	res := &ReadinessResponse{Message: StatusReady, Ready: true}
+	if len(h.checkers) == 0 {
+		return res, nil
+	}
+
+	res.Components = make(map[string]string, len(h.checkers))
+	for name, checker := range h.checkers {
+		if checker.Healthy(ctx) {
+			res.Components[name] = "ready"
+			continue
+		}
+		res.Components[name] = "pending"
+		res.Message = StatusNotReady
+		res.Ready = false
+	}
+	return res, nil
+}
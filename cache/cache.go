@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrMiss, aranan anahtar cache'te bulunmadığında döner.
+var ErrMiss = errors.New("cache miss")
+
+// Cache, controllerların kullandığı anahtar/değer deposudur.
+// ttl=0 verilirse kayıt süresiz saklanır.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	// Incr, sayacı bir artırıp yeni değeri döner; anahtar yoksa 0'dan başlar.
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+// Ranking, sıralı kümeleri destekleyen cache'lerin (ör. Redis ZSET)
+// implemente ettiği arayüzdür; en sık yapılan sorguları saymak için
+// kullanılır.
+type Ranking interface {
+	// Rank, member'ın skorunu bir artırır. Skorlar zaman pencerelerinde
+	// tutulur; pencere keep elemandan büyürse en düşük skorlu elemanlar
+	// atılır.
+	Rank(ctx context.Context, key, member string, keep int) error
+	// Top, son pencerelerde en yüksek skorlu n elemanı büyükten küçüğe
+	// döner.
+	Top(ctx context.Context, key string, n int) ([]string, error)
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Key, parçaları ":" ile birleştirir; ör. Key("v1", "actors", "list", hash).
+func Key(parts ...string) string {
+	return strings.Join(parts, ":")
+}
+
+// Hash, parametreleri anahtar sırasına göre kanonik hale getirip kısaltılmış
+// bir sha256 özeti döner. Aynı sorgu her zaman aynı özete düşer.
+func Hash(params url.Values) string {
+	sum := sha256.Sum256([]byte(params.Encode()))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Params, query etiketli struct alanlarını url.Values'a çevirir. Sorgu
+// struct'ına eklenen her yeni parametre böylece cache anahtarına da girer.
+func Params(v any) url.Values {
+	params := url.Values{}
+
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		name, _, _ := strings.Cut(rt.Field(i).Tag.Get("query"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		field := rv.Field(i)
+		if field.Kind() == reflect.Slice {
+			for j := range field.Len() {
+				params.Add(name, fmt.Sprint(field.Index(j).Interface()))
+			}
+			continue
+		}
+		params.Set(name, fmt.Sprint(field.Interface()))
+	}
+
+	return params
+}
@@ -0,0 +1,255 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Locker, birden fazla replikanın aynı değeri aynı anda hesaplamasını
+// engelleyen dağıtık kilittir. Cache implementasyonu bunu destekliyorsa
+// Loader kilidi kullanır.
+type Locker interface {
+	// Lock, kilit alınamazsa ok=false döner; unlock yalnızca ok=true iken
+	// geçerlidir.
+	Lock(ctx context.Context, key string, ttl time.Duration) (unlock func(), ok bool, err error)
+}
+
+type LoaderConfig struct {
+	// Prefix, metriklerde kullanılan anahtar önekidir (ör. "v1:actors").
+	// Anahtarın kendisi etiket olarak kullanılmaz.
+	Prefix string
+	// TTL, değerin taze sayıldığı süredir.
+	TTL time.Duration
+	// StaleTTL sıfırdan büyükse değer TTL dolduktan sonra bu süre kadar daha
+	// saklanır; bu sürede bayat değer dönülür ve arka planda yenilenir.
+	StaleTTL time.Duration
+	// Negative, load bu hatayı dönerse sonuç NegativeTTL kadar cache'lenir.
+	Negative    error
+	NegativeTTL time.Duration
+	// LockTTL, dağıtık kilidin en uzun tutulma süresidir.
+	LockTTL time.Duration
+}
+
+// Loader, read-through cache'tir: değer cache'te yoksa load çağrılır ve
+// sonucu saklanır. Aynı anahtar için eşzamanlı istekler tek bir load'da
+// birleştirilir; Cache Locker'ı destekliyorsa replikalar arasında da
+// yalnızca biri hesaplama yapar.
+type Loader struct {
+	cache  Cache
+	locker Locker
+	config LoaderConfig
+	group  singleflight.Group
+}
+
+func NewLoader(cache Cache, config LoaderConfig) *Loader {
+	locker, _ := cache.(Locker)
+	return &Loader{
+		cache:  cache,
+		locker: locker,
+		config: config,
+	}
+}
+
+type loadFunc func(ctx context.Context) ([]byte, error)
+
+// Load, cache hatalarını loglar ve load'a düşer; cache'in çalışmaması
+// isteği başarısız yapmaz.
+func (l *Loader) Load(ctx context.Context, key string, load func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	if e, ok := l.get(ctx, key); ok {
+		if e.fresh() {
+			return e.result(l.config.Negative)
+		}
+		if l.config.StaleTTL > 0 {
+			l.refresh(ctx, key, load)
+			return e.result(l.config.Negative)
+		}
+	}
+
+	v, err, _ := l.group.Do(key, func() (any, error) {
+		return l.fill(context.WithoutCancel(ctx), key, load, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*entry).result(l.config.Negative)
+}
+
+// refresh, bayat değeri arka planda yeniler. Başka bir replika kilidi
+// tutuyorsa yenileme ona bırakılır.
+func (l *Loader) refresh(ctx context.Context, key string, load loadFunc) {
+	l.group.DoChan("refresh:"+key, func() (any, error) {
+		return l.fill(context.WithoutCancel(ctx), key, load, true)
+	})
+}
+
+func (l *Loader) fill(ctx context.Context, key string, load loadFunc, refresh bool) (*entry, error) {
+	if l.locker != nil {
+		unlock, ok, err := l.locker.Lock(ctx, lockKey(key), l.lockTTL())
+		switch {
+		case err != nil:
+			Bypass("lock", key, err)
+		case ok:
+			defer unlock()
+		case refresh:
+			return nil, nil
+		default:
+			if e, ok := l.wait(ctx, key); ok {
+				return e, nil
+			}
+		}
+	}
+
+	value, err := load(ctx)
+	if err != nil {
+		if l.config.Negative != nil && l.config.NegativeTTL > 0 && errors.Is(err, l.config.Negative) {
+			e := &entry{negative: true, freshUntil: time.Now().Add(l.config.NegativeTTL)}
+			l.set(ctx, key, e, l.config.NegativeTTL)
+			return e, nil
+		}
+		return nil, err
+	}
+
+	e := &entry{value: value, freshUntil: time.Now().Add(l.config.TTL)}
+	l.set(ctx, key, e, l.config.TTL+l.config.StaleTTL)
+	return e, nil
+}
+
+// wait, kilidi tutan replikanın değeri yazmasını bekler.
+func (l *Loader) wait(ctx context.Context, key string) (*entry, bool) {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	deadline := time.After(l.lockTTL())
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-deadline:
+			return nil, false
+		case <-ticker.C:
+			// Bekleme sırasındaki okumalar metriklere yansıtılmaz.
+			if value, err := l.cache.Get(ctx, key); err == nil {
+				if e := decodeEntry(value); e.fresh() {
+					return e, true
+				}
+			}
+		}
+	}
+}
+
+func (l *Loader) lockTTL() time.Duration {
+	if l.config.LockTTL > 0 {
+		return l.config.LockTTL
+	}
+	return 5 * time.Second
+}
+
+func (l *Loader) get(ctx context.Context, key string) (*entry, bool) {
+	defer observeDuration(l.prefix(), "get", time.Now())
+
+	value, err := l.cache.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrMiss) {
+			requestsTotal.WithLabelValues(l.prefix(), "miss").Inc()
+		} else {
+			requestsTotal.WithLabelValues(l.prefix(), "error").Inc()
+			Bypass("get", key, err)
+		}
+		return nil, false
+	}
+
+	e := decodeEntry(value)
+	if e.fresh() {
+		requestsTotal.WithLabelValues(l.prefix(), "hit").Inc()
+	} else {
+		requestsTotal.WithLabelValues(l.prefix(), "stale").Inc()
+	}
+	return e, true
+}
+
+func (l *Loader) set(ctx context.Context, key string, e *entry, ttl time.Duration) {
+	defer observeDuration(l.prefix(), "set", time.Now())
+
+	value, err := e.encode()
+	if err == nil {
+		err = l.cache.Set(ctx, key, value, ttl)
+	}
+	if err != nil {
+		setFailuresTotal.WithLabelValues(l.prefix()).Inc()
+		Bypass("set", key, err)
+	}
+}
+
+func (l *Loader) prefix() string {
+	if l.config.Prefix == "" {
+		return "default"
+	}
+	return l.config.Prefix
+}
+
+func lockKey(key string) string {
+	return Key("lock", key)
+}
+
+// entry, Loader'ın sakladığı kayıttır. Cache katmanındaki codec'lerin
+// (ör. MessagePack) değeri dönüştürebilmesi için kayıt JSON olarak saklanır:
+//
+//	{"$entry":1,"fresh_until":<unix nano>,"negative":true,"value":<değer>}
+//
+// Bu nedenle Loader'a verilen değerler geçerli JSON olmalıdır. Bu formatta
+// olmayan eski kayıtlar taze değer olarak okunur.
+type entry struct {
+	value      []byte
+	negative   bool
+	freshUntil time.Time
+}
+
+const entryVersion = 1
+
+var entryPrefix = []byte(`{"$entry":`)
+
+type entryJSON struct {
+	Version    int             `json:"$entry"`
+	FreshUntil int64           `json:"fresh_until"`
+	Negative   bool            `json:"negative,omitempty"`
+	Value      json.RawMessage `json:"value,omitempty"`
+}
+
+func (e *entry) fresh() bool {
+	return e.freshUntil.IsZero() || time.Now().Before(e.freshUntil)
+}
+
+func (e *entry) result(negative error) ([]byte, error) {
+	if e.negative {
+		return nil, negative
+	}
+	return e.value, nil
+}
+
+func (e *entry) encode() ([]byte, error) {
+	return json.Marshal(entryJSON{
+		Version:    entryVersion,
+		FreshUntil: e.freshUntil.UnixNano(),
+		Negative:   e.negative,
+		Value:      e.value,
+	})
+}
+
+func decodeEntry(value []byte) *entry {
+	if bytes.HasPrefix(value, entryPrefix) {
+		var v entryJSON
+		if err := json.Unmarshal(value, &v); err == nil && v.Version == entryVersion {
+			return &entry{
+				value:      v.Value,
+				negative:   v.Negative,
+				freshUntil: time.Unix(0, v.FreshUntil),
+			}
+		}
+	}
+	return &entry{value: value}
+}
@@ -0,0 +1,386 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeCache, testler için süreç içi Cache'tir; Set'e verilen TTL'leri
+// kaydeder ama uygulamaz.
+type fakeCache struct {
+	mu     sync.Mutex
+	values map[string][]byte
+	ttls   map[string]time.Duration
+	getErr error
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{values: make(map[string][]byte), ttls: make(map[string]time.Duration)}
+}
+
+func (c *fakeCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.getErr != nil {
+		return nil, c.getErr
+	}
+	value, ok := c.values[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+	return value, nil
+}
+
+func (c *fakeCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+	c.ttls[key] = ttl
+	return nil
+}
+
+func (c *fakeCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.values, key)
+	}
+	return nil
+}
+
+func (c *fakeCache) Incr(context.Context, string) (int64, error) { return 0, nil }
+
+func (c *fakeCache) entry(key string) *entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.values[key]
+	if !ok {
+		return nil
+	}
+	return decodeEntry(value)
+}
+
+// lockingCache, kilidi başka bir replikanın tuttuğu durumu taklit eder:
+// held true iken Lock ok=false döner.
+type lockingCache struct {
+	*fakeCache
+	held  atomic.Bool
+	locks atomic.Int64
+}
+
+func (c *lockingCache) Lock(context.Context, string, time.Duration) (func(), bool, error) {
+	c.locks.Add(1)
+	if c.held.Load() {
+		return nil, false, nil
+	}
+	return func() {}, true, nil
+}
+
+var errNotFound = errors.New("not found")
+
+func encoded(t *testing.T, e *entry) []byte {
+	t.Helper()
+	value, err := e.encode()
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	return value
+}
+
+func TestLoaderLoad(t *testing.T) {
+	config := LoaderConfig{
+		TTL:         time.Minute,
+		Negative:    errNotFound,
+		NegativeTTL: 10 * time.Second,
+	}
+	past := time.Now().Add(-time.Second)
+
+	tests := []struct {
+		name   string
+		config LoaderConfig
+		// seed, anahtarın başlangıç değeridir; nil ise anahtar yoktur.
+		seed    func(t *testing.T) []byte
+		getErr  error
+		loadErr error
+		want    string
+		wantErr error
+		// wantLoads, load'un kaç kez çağrıldığıdır.
+		wantLoads int
+		// wantTTL, Set'e verilen TTL'dir; 0 ise Set çağrılmamalıdır.
+		wantTTL time.Duration
+	}{
+		{
+			name:      "miss loads and stores",
+			config:    config,
+			want:      `"loaded"`,
+			wantLoads: 1,
+			wantTTL:   time.Minute,
+		},
+		{
+			name:   "fresh hit",
+			config: config,
+			seed: func(t *testing.T) []byte {
+				return encoded(t, &entry{value: []byte(`"cached"`), freshUntil: time.Now().Add(time.Minute)})
+			},
+			want: `"cached"`,
+		},
+		{
+			name:   "value without entry header is fresh",
+			config: config,
+			seed:   func(*testing.T) []byte { return []byte(`"legacy"`) },
+			want:   `"legacy"`,
+		},
+		{
+			name:   "stale without stale ttl loads synchronously",
+			config: config,
+			seed: func(t *testing.T) []byte {
+				return encoded(t, &entry{value: []byte(`"stale"`), freshUntil: past})
+			},
+			want:      `"loaded"`,
+			wantLoads: 1,
+			wantTTL:   time.Minute,
+		},
+		{
+			name:      "stored ttl includes stale ttl",
+			config:    LoaderConfig{TTL: time.Minute, StaleTTL: 30 * time.Second},
+			want:      `"loaded"`,
+			wantLoads: 1,
+			wantTTL:   time.Minute + 30*time.Second,
+		},
+		{
+			name:      "negative result is cached",
+			config:    config,
+			loadErr:   errNotFound,
+			wantErr:   errNotFound,
+			wantLoads: 1,
+			wantTTL:   10 * time.Second,
+		},
+		{
+			name:   "cached negative result",
+			config: config,
+			seed: func(t *testing.T) []byte {
+				return encoded(t, &entry{negative: true, freshUntil: time.Now().Add(time.Minute)})
+			},
+			wantErr: errNotFound,
+		},
+		{
+			name:      "other errors are not cached",
+			config:    config,
+			loadErr:   errors.New("database down"),
+			wantErr:   errors.New("database down"),
+			wantLoads: 1,
+		},
+		{
+			name:      "negative caching disabled",
+			config:    LoaderConfig{TTL: time.Minute, Negative: errNotFound},
+			loadErr:   errNotFound,
+			wantErr:   errNotFound,
+			wantLoads: 1,
+		},
+		{
+			name:      "cache error falls back to load",
+			config:    config,
+			getErr:    errors.New("connection refused"),
+			want:      `"loaded"`,
+			wantLoads: 1,
+			wantTTL:   time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeCache()
+			if tt.seed != nil {
+				c.values["k"] = tt.seed(t)
+			}
+			c.getErr = tt.getErr
+
+			loads := 0
+			got, err := NewLoader(c, tt.config).Load(context.Background(), "k", func(context.Context) ([]byte, error) {
+				loads++
+				if tt.loadErr != nil {
+					return nil, tt.loadErr
+				}
+				return []byte(`"loaded"`), nil
+			})
+
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("Load error = %v", err)
+			case tt.wantErr != nil && (err == nil || err.Error() != tt.wantErr.Error()):
+				t.Fatalf("Load error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("Load = %s, want %s", got, tt.want)
+			}
+			if loads != tt.wantLoads {
+				t.Fatalf("load called %d times, want %d", loads, tt.wantLoads)
+			}
+			ttl, stored := c.ttls["k"]
+			if stored != (tt.wantTTL > 0) || ttl != tt.wantTTL {
+				t.Fatalf("stored ttl = %v (stored %v), want %v", ttl, stored, tt.wantTTL)
+			}
+		})
+	}
+}
+
+func TestLoaderNegativeCacheHit(t *testing.T) {
+	l := NewLoader(newFakeCache(), LoaderConfig{TTL: time.Minute, Negative: errNotFound, NegativeTTL: time.Minute})
+
+	loads := 0
+	load := func(context.Context) ([]byte, error) {
+		loads++
+		return nil, errNotFound
+	}
+	for range 3 {
+		if _, err := l.Load(context.Background(), "k", load); !errors.Is(err, errNotFound) {
+			t.Fatalf("Load error = %v, want errNotFound", err)
+		}
+	}
+	if loads != 1 {
+		t.Fatalf("load called %d times, want 1", loads)
+	}
+}
+
+func TestLoaderSingleflight(t *testing.T) {
+	const n = 50
+
+	l := NewLoader(newFakeCache(), LoaderConfig{TTL: time.Minute})
+
+	var loads atomic.Int64
+	release := make(chan struct{})
+	load := func(context.Context) ([]byte, error) {
+		loads.Add(1)
+		<-release
+		return []byte(`"v"`), nil
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan string, n)
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := l.Load(context.Background(), "k", load)
+			if err != nil {
+				t.Errorf("Load: %v", err)
+			}
+			results <- string(value)
+		}()
+	}
+	// Tüm isteklerin load'u beklemesi için kısa bir süre tanınır.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	if got := loads.Load(); got != 1 {
+		t.Fatalf("load called %d times for %d concurrent requests, want 1", got, n)
+	}
+	for value := range results {
+		if value != `"v"` {
+			t.Fatalf("Load = %s, want \"v\"", value)
+		}
+	}
+}
+
+func TestLoaderLockContention(t *testing.T) {
+	tests := []struct {
+		name string
+		// other, kilidi tutan replikanın yazdığı değerdir; boşsa hiç yazmaz.
+		other     string
+		want      string
+		wantLoads int
+	}{
+		{name: "waits for the lock holder", other: `"other"`, want: `"other"`},
+		{name: "loads after lock ttl", want: `"loaded"`, wantLoads: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &lockingCache{fakeCache: newFakeCache()}
+			c.held.Store(true)
+			l := NewLoader(c, LoaderConfig{TTL: time.Minute, LockTTL: 300 * time.Millisecond})
+
+			if tt.other != "" {
+				go func() {
+					time.Sleep(100 * time.Millisecond)
+					e := &entry{value: []byte(tt.other), freshUntil: time.Now().Add(time.Minute)}
+					c.Set(context.Background(), "k", encoded(t, e), time.Minute)
+				}()
+			}
+
+			loads := 0
+			got, err := l.Load(context.Background(), "k", func(context.Context) ([]byte, error) {
+				loads++
+				return []byte(`"loaded"`), nil
+			})
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if string(got) != tt.want || loads != tt.wantLoads {
+				t.Fatalf("Load = %s with %d loads, want %s with %d", got, loads, tt.want, tt.wantLoads)
+			}
+			if c.locks.Load() != 1 {
+				t.Fatalf("Lock called %d times, want 1", c.locks.Load())
+			}
+		})
+	}
+}
+
+func TestLoaderStaleWhileRevalidate(t *testing.T) {
+	tests := []struct {
+		name string
+		// held, yenilemeyi başka bir replikanın yaptığını belirtir.
+		held bool
+		want string
+	}{
+		{name: "refreshes in the background", want: `"fresh"`},
+		{name: "leaves refresh to the lock holder", held: true, want: `"stale"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &lockingCache{fakeCache: newFakeCache()}
+			c.held.Store(tt.held)
+			c.values["k"] = encoded(t, &entry{value: []byte(`"stale"`), freshUntil: time.Now().Add(-time.Second)})
+			l := NewLoader(c, LoaderConfig{TTL: time.Minute, StaleTTL: time.Minute})
+
+			refreshed := make(chan struct{})
+			got, err := l.Load(context.Background(), "k", func(context.Context) ([]byte, error) {
+				defer close(refreshed)
+				return []byte(`"fresh"`), nil
+			})
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if string(got) != `"stale"` {
+				t.Fatalf("Load = %s, want the stale value", got)
+			}
+
+			if !tt.held {
+				select {
+				case <-refreshed:
+				case <-time.After(time.Second):
+					t.Fatal("stale value was not refreshed")
+				}
+			}
+			// Yenileme load döndükten sonra yazılır.
+			deadline := time.Now().Add(time.Second)
+			for {
+				e := c.entry("k")
+				if string(e.value) == tt.want || time.Now().After(deadline) {
+					if string(e.value) != tt.want {
+						t.Fatalf("cached value = %s, want %s", e.value, tt.want)
+					}
+					if tt.want == `"fresh"` && !e.fresh() {
+						t.Fatal("refreshed value is not fresh")
+					}
+					break
+				}
+				time.Sleep(5 * time.Millisecond)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache, girdi sayısı ve toplam boyutla sınırlı, girdi başına TTL'li
+// süreç içi bir LRU cache'tir. Sınır aşıldığında en uzun süredir
+// kullanılmayan girdiler atılır.
+type Cache struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	bytes      int64
+	order      *list.List
+	items      map[string]*list.Element
+}
+
+type item struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// New, sınırlardan biri 0 ise o sınırı uygulamaz.
+func New(maxEntries int, maxBytes int64) *Cache {
+	return &Cache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		order:      list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	it := el.Value.(*item)
+	if !it.expiresAt.IsZero() && time.Now().After(it.expiresAt) {
+		c.remove(el)
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	return it.value, true
+}
+
+// Set, ttl=0 ise girdiyi yalnızca boyut sınırlarıyla tutar.
+func (c *Cache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Sığmayan değer tutulmaz; anahtarın eski değeri de atılır, aksi halde
+	// Redis'teki yeni değer yerine eskisi okunmaya devam eder.
+	if c.maxBytes > 0 && int64(len(value)) > c.maxBytes {
+		if el, ok := c.items[key]; ok {
+			c.remove(el)
+		}
+		return
+	}
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		it := el.Value.(*item)
+		c.bytes += int64(len(value)) - int64(len(it.value))
+		it.value = value
+		it.expiresAt = expiresAt
+		c.order.MoveToFront(el)
+	} else {
+		c.items[key] = c.order.PushFront(&item{key: key, value: value, expiresAt: expiresAt})
+		c.bytes += int64(len(value))
+	}
+
+	for c.overLimit() {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *Cache) Delete(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := c.items[key]; ok {
+			c.remove(el)
+		}
+	}
+}
+
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.items = make(map[string]*list.Element)
+	c.bytes = 0
+}
+
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *Cache) overLimit() bool {
+	return (c.maxEntries > 0 && c.order.Len() > c.maxEntries) ||
+		(c.maxBytes > 0 && c.bytes > c.maxBytes)
+}
+
+func (c *Cache) remove(el *list.Element) {
+	it := c.order.Remove(el).(*item)
+	delete(c.items, it.key)
+	c.bytes -= int64(len(it.value))
+}
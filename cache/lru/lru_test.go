@@ -0,0 +1,155 @@
+package lru
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// keys, girdileri en son kullanılandan en eskiye doğru döner.
+func (c *Cache) keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var keys []string
+	for el := c.order.Front(); el != nil; el = el.Next() {
+		keys = append(keys, el.Value.(*item).key)
+	}
+	return keys
+}
+
+func TestEviction(t *testing.T) {
+	type op struct {
+		get   string
+		set   string
+		value string
+	}
+	tests := []struct {
+		name       string
+		maxEntries int
+		maxBytes   int64
+		ops        []op
+		want       []string
+		wantBytes  int64
+	}{
+		{
+			name:       "oldest entry is evicted",
+			maxEntries: 2,
+			ops:        []op{{set: "a", value: "1"}, {set: "b", value: "2"}, {set: "c", value: "3"}},
+			want:       []string{"c", "b"},
+			wantBytes:  2,
+		},
+		{
+			name:       "get marks entry as recently used",
+			maxEntries: 2,
+			ops:        []op{{set: "a", value: "1"}, {set: "b", value: "2"}, {get: "a"}, {set: "c", value: "3"}},
+			want:       []string{"c", "a"},
+			wantBytes:  2,
+		},
+		{
+			name:       "set on existing key marks it as recently used",
+			maxEntries: 2,
+			ops:        []op{{set: "a", value: "1"}, {set: "b", value: "2"}, {set: "a", value: "11"}, {set: "c", value: "3"}},
+			want:       []string{"c", "a"},
+			wantBytes:  3,
+		},
+		{
+			name:      "byte limit evicts until the new value fits",
+			maxBytes:  6,
+			ops:       []op{{set: "a", value: "111"}, {set: "b", value: "222"}, {set: "c", value: "3333"}},
+			want:      []string{"c"},
+			wantBytes: 4,
+		},
+		{
+			name:      "growing a value counts its new size",
+			maxBytes:  6,
+			ops:       []op{{set: "a", value: "11"}, {set: "b", value: "22"}, {set: "a", value: "11111"}},
+			want:      []string{"a"},
+			wantBytes: 5,
+		},
+		{
+			name:      "oversized value drops the key's old value",
+			maxBytes:  4,
+			ops:       []op{{set: "a", value: "11"}, {set: "b", value: "22"}, {set: "a", value: "11111"}},
+			want:      []string{"b"},
+			wantBytes: 2,
+		},
+		{
+			name:      "no limits",
+			ops:       []op{{set: "a", value: "1"}, {set: "b", value: "2"}, {set: "c", value: "3"}},
+			want:      []string{"c", "b", "a"},
+			wantBytes: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(tt.maxEntries, tt.maxBytes)
+			for _, op := range tt.ops {
+				if op.get != "" {
+					c.Get(op.get)
+					continue
+				}
+				c.Set(op.set, []byte(op.value), 0)
+			}
+			if got := c.keys(); !slices.Equal(got, tt.want) {
+				t.Fatalf("keys = %v, want %v", got, tt.want)
+			}
+			if c.bytes != tt.wantBytes {
+				t.Fatalf("bytes = %d, want %d", c.bytes, tt.wantBytes)
+			}
+		})
+	}
+}
+
+func TestTTL(t *testing.T) {
+	c := New(0, 0)
+	c.Set("short", []byte("1"), 20*time.Millisecond)
+	c.Set("forever", []byte("2"), 0)
+
+	if _, ok := c.Get("short"); !ok {
+		t.Fatal("entry expired before its ttl")
+	}
+	time.Sleep(40 * time.Millisecond)
+
+	if _, ok := c.Get("short"); ok {
+		t.Fatal("expired entry was returned")
+	}
+	if _, ok := c.Get("forever"); !ok {
+		t.Fatal("entry without ttl expired")
+	}
+	if c.Len() != 1 || c.bytes != 1 {
+		t.Fatalf("expired entry was not removed: len %d, bytes %d", c.Len(), c.bytes)
+	}
+
+	// Yeni Set TTL'yi yeniler.
+	c.Set("short", []byte("1"), 20*time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	c.Set("short", []byte("1"), 20*time.Millisecond)
+	time.Sleep(15 * time.Millisecond)
+	if _, ok := c.Get("short"); !ok {
+		t.Fatal("set did not renew the ttl")
+	}
+}
+
+func TestDeleteAndPurge(t *testing.T) {
+	c := New(0, 0)
+	c.Set("a", []byte("11"), 0)
+	c.Set("b", []byte("22"), 0)
+	c.Set("c", []byte("33"), 0)
+
+	c.Delete("a", "missing")
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("deleted entry was returned")
+	}
+	if c.Len() != 2 || c.bytes != 4 {
+		t.Fatalf("after delete: len %d, bytes %d; want 2, 4", c.Len(), c.bytes)
+	}
+
+	c.Purge()
+	if c.Len() != 0 || c.bytes != 0 {
+		t.Fatalf("after purge: len %d, bytes %d; want 0, 0", c.Len(), c.bytes)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("purged entry was returned")
+	}
+}
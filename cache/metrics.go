@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var bypassTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "cache_bypass_total",
+	Help: "Cache hatası nedeniyle atlanan cache işlemleri.",
+}, []string{"operation"})
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_requests_total",
+		Help: "Anahtar önekine göre cache okumaları; result hit, stale, miss veya error olur.",
+	}, []string{"prefix", "result"})
+
+	setFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_set_failures_total",
+		Help: "Anahtar önekine göre başarısız cache yazmaları.",
+	}, []string{"prefix"})
+
+	operationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cache_operation_duration_seconds",
+		Help:    "Anahtar önekine göre cache okuma/yazma süreleri.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"prefix", "operation"})
+)
+
+// bypassLogInterval, bypass uyarılarının en sık yazılma aralığıdır. Kesinti
+// sırasında her istek birkaç kez bypass eder; istek bazındaki sayı
+// cache_bypass_total'dadır.
+const bypassLogInterval = 10 * time.Second
+
+var (
+	lastBypassLog      atomic.Int64
+	suppressedBypasses atomic.Int64
+)
+
+// Bypass, cache hatası yüzünden cache'in atlandığını sayar ve en fazla
+// bypassLogInterval'da bir loglar; aradaki bypass'ların sayısı logdaki
+// suppressed alanıdır. İstek cache olmadan devam eder.
+func Bypass(operation, key string, err error) {
+	bypassTotal.WithLabelValues(operation).Inc()
+
+	now := time.Now().UnixNano()
+	last := lastBypassLog.Load()
+	if now-last < int64(bypassLogInterval) || !lastBypassLog.CompareAndSwap(last, now) {
+		suppressedBypasses.Add(1)
+		return
+	}
+	zap.L().Warn("cache bypassed",
+		zap.String("operation", operation),
+		zap.String("key", key),
+		zap.Int64("suppressed", suppressedBypasses.Swap(0)),
+		zap.Error(err))
+}
+
+func observeDuration(prefix, operation string, start time.Time) {
+	operationDuration.WithLabelValues(prefix, operation).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,202 @@
+package redis
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec, değerin Redis'te hangi biçimde saklanacağını belirler. Encode
+// JSON değer alır; Decode aynı JSON'u geri üretmelidir.
+type Codec interface {
+	Name() string
+	Encode(value []byte) ([]byte, error)
+	Decode(data []byte) ([]byte, error)
+}
+
+// Her kodlanmış değerin ilk baytı codec'i belirtir. Başlıklar, eski
+// sürümlerin yazdığı başlıksız değerlerle (JSON metni ve sayaçlar)
+// çakışmayacak aralıktan seçilmiştir; başlığı tanınmayan değerler olduğu
+// gibi döner. Böylece geçiş sırasında eski ve yeni
+// sürümlerin yazdığı değerler birlikte okunabilir.
+const (
+	headerJSON    byte = 0xE1
+	headerMsgpack byte = 0xE2
+	headerGzip    byte = 0xE3
+	headerZstd    byte = 0xE4
+)
+
+var codecs = map[byte]Codec{
+	headerJSON:    jsonCodec{},
+	headerMsgpack: msgpackCodec{},
+	headerGzip:    gzipCodec{},
+	headerZstd:    zstdCodec{},
+}
+
+// codecByName, redis.codec ayarındaki isme karşılık gelen codec'i ve
+// başlığını döner. Boş isim JSON'dur.
+func codecByName(name string) (byte, Codec, error) {
+	if name == "" {
+		name = "json"
+	}
+	for header, c := range codecs {
+		if c.Name() == strings.ToLower(name) {
+			return header, c, nil
+		}
+	}
+	return 0, nil, fmt.Errorf("%w: unknown codec %q", ErrInvalidConfig, name)
+}
+
+// encode, değeri codec ile kodlar ve başlığı ekler. Codec değeri
+// kodlayamazsa (ör. MessagePack için JSON olmayan değer) değer JSON
+// codec'iyle, yani olduğu gibi saklanır.
+func encode(header byte, c Codec, value []byte) []byte {
+	data, err := c.Encode(value)
+	if err != nil {
+		header, c, data = headerJSON, codecs[headerJSON], value
+	}
+	observeSize(c.Name(), len(value), len(data)+1)
+
+	buf := make([]byte, 0, len(data)+1)
+	buf = append(buf, header)
+	return append(buf, data...)
+}
+
+func decode(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+	c, ok := codecs[data[0]]
+	if !ok {
+		return data, nil
+	}
+	return c.Decode(data[1:])
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string                        { return "json" }
+func (jsonCodec) Encode(value []byte) ([]byte, error) { return value, nil }
+func (jsonCodec) Decode(data []byte) ([]byte, error)  { return data, nil }
+
+// msgpackCodec, JSON değeri MessagePack'e çevirir. Nesne anahtarlarının
+// sırası korunmaz; tam sayılar tam sayı olarak kalır.
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string { return "msgpack" }
+
+func (msgpackCodec) Encode(value []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(value))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("msgpack: trailing data after JSON value")
+	}
+	return msgpack.Marshal(fromJSON(v))
+}
+
+func (msgpackCodec) Decode(data []byte) ([]byte, error) {
+	var v any
+	if err := msgpack.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// fromJSON, json.Number değerlerini MessagePack'in sayı tiplerine çevirir.
+func fromJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = fromJSON(e)
+		}
+		return v
+	case []any:
+		for i, e := range v {
+			v[i] = fromJSON(e)
+		}
+		return v
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	default:
+		return v
+	}
+}
+
+type gzipCodec struct{}
+
+func (gzipCodec) Name() string { return "gzip" }
+
+func (gzipCodec) Encode(value []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(value); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipCodec) Decode(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// zstd encoder ve decoder'ları eşzamanlı kullanıma uygundur; her çağrıda
+// yeniden oluşturmamak için paylaşılır.
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+func zstdInit() error {
+	zstdOnce.Do(func() {
+		zstdEncoder, zstdErr = zstd.NewWriter(nil)
+		if zstdErr != nil {
+			return
+		}
+		zstdDecoder, zstdErr = zstd.NewReader(nil)
+	})
+	return zstdErr
+}
+
+type zstdCodec struct{}
+
+func (zstdCodec) Name() string { return "zstd" }
+
+func (zstdCodec) Encode(value []byte) ([]byte, error) {
+	if err := zstdInit(); err != nil {
+		return nil, err
+	}
+	return zstdEncoder.EncodeAll(value, nil), nil
+}
+
+func (zstdCodec) Decode(data []byte) ([]byte, error) {
+	if err := zstdInit(); err != nil {
+		return nil, err
+	}
+	return zstdDecoder.DecodeAll(data, nil)
+}
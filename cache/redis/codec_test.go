@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	values := []string{
+		`{"actor":{"ID":1,"FirstName":"Penelope","LastName":"Guiness","LastUpdate":"2013-05-26T14:47:57.62Z"}}`,
+		`{"$entry":1,"fresh_until":1700000000000000000,"value":{"count":200,"ratio":0.5,"next":null}}`,
+		`[1,"two",3.5,true,null,{"nested":[]}]`,
+		`"şükrü"`,
+		`42`,
+	}
+	for _, name := range []string{"json", "msgpack", "gzip", "zstd"} {
+		header, c, err := codecByName(name)
+		if err != nil {
+			t.Fatalf("codecByName(%q): %v", name, err)
+		}
+		for _, value := range values {
+			t.Run(name+"/"+value[:min(len(value), 12)], func(t *testing.T) {
+				data := encode(header, c, []byte(value))
+				if data[0] != header {
+					t.Fatalf("header = %#x, want %#x", data[0], header)
+				}
+				got, err := decode(data)
+				if err != nil {
+					t.Fatalf("decode: %v", err)
+				}
+				assertSameJSON(t, got, []byte(value))
+			})
+		}
+	}
+}
+
+func TestCodecHeaders(t *testing.T) {
+	want := map[string]byte{"json": 0xE1, "msgpack": 0xE2, "gzip": 0xE3, "zstd": 0xE4, "": 0xE1, "GZIP": 0xE3}
+	for name, header := range want {
+		got, _, err := codecByName(name)
+		if err != nil || got != header {
+			t.Errorf("codecByName(%q) = %#x, %v; want %#x", name, got, err, header)
+		}
+	}
+	if _, _, err := codecByName("snappy"); err == nil {
+		t.Error("codecByName accepted an unknown codec")
+	}
+}
+
+// TestEncodeFallsBackToJSON, codec'in kodlayamadığı değerlerin JSON
+// başlığıyla olduğu gibi saklandığını doğrular.
+func TestEncodeFallsBackToJSON(t *testing.T) {
+	header, c, _ := codecByName("msgpack")
+	value := []byte("not json")
+
+	data := encode(header, c, value)
+	if data[0] != headerJSON {
+		t.Fatalf("header = %#x, want %#x", data[0], headerJSON)
+	}
+	got, err := decode(data)
+	if err != nil || !bytes.Equal(got, value) {
+		t.Fatalf("decode = %q, %v; want %q", got, err, value)
+	}
+}
+
+// TestDecodeWithoutHeader, başlıksız eski değerlerin olduğu gibi döndüğünü
+// doğrular.
+func TestDecodeWithoutHeader(t *testing.T) {
+	values := [][]byte{
+		[]byte(`{"actor":{"ID":1}}`),
+		[]byte(`[]`),
+		[]byte(`"text"`),
+		[]byte(`17`),
+		[]byte{},
+		[]byte{0xE0, 0x01},
+		[]byte{0xE5, 0x01},
+	}
+	for _, value := range values {
+		got, err := decode(value)
+		if err != nil || !bytes.Equal(got, value) {
+			t.Errorf("decode(%q) = %q, %v; want it unchanged", value, got, err)
+		}
+	}
+}
+
+func assertSameJSON(t *testing.T, got, want []byte) {
+	t.Helper()
+	var g, w any
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("decoded value is not JSON: %q", got)
+	}
+	if err := json.Unmarshal(want, &w); err != nil {
+		t.Fatalf("want is not JSON: %q", want)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Fatalf("decoded %s, want %s", got, want)
+	}
+}
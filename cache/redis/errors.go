@@ -3,8 +3,12 @@ package redis
 import "errors"
 
 var (
-	ErrURLParseFailed   = errors.New("url parse failed")
-	ErrConnectionFailed = errors.New("url connection failed")
+	ErrInvalidConfig    = errors.New("invalid redis config")
 	ErrSetDataFailed    = errors.New("set data failed")
 	ErrGetDataFailed    = errors.New("get data failed")
+	ErrDeleteDataFailed = errors.New("delete data failed")
+	ErrIncrFailed       = errors.New("incr failed")
+	ErrLockFailed       = errors.New("lock failed")
+	ErrPublishFailed    = errors.New("publish failed")
+	ErrUnavailable      = errors.New("redis unavailable")
 )
@@ -0,0 +1,101 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// maxPending, Redis erişilemezken biriktirilecek en fazla invalidation
+// sayısıdır.
+const maxPending = 10000
+
+// Healthy, son bilinen bağlantı durumunu döner.
+func (h *Handler) Healthy(ctx context.Context) bool {
+	return h.healthy.Load()
+}
+
+// monitor, bağlantıyı düzenli aralıklarla kontrol eder. Bağlantı geri
+// geldiğinde bekleyen invalidation'ları uygular.
+func (h *Handler) monitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pingCtx, cancel := context.WithTimeout(ctx, interval)
+		err := h.client.Ping(pingCtx).Err()
+		cancel()
+
+		switch {
+		case err != nil && h.healthy.Swap(false):
+			zap.L().Warn("redis connection lost, cache bypassed", zap.Error(err))
+		case err == nil && !h.healthy.Load():
+			if err := h.flushPending(ctx); err != nil {
+				zap.L().Warn("redis pending invalidations failed", zap.Error(err))
+				continue
+			}
+			h.healthy.Store(true)
+			zap.L().Info("redis connection restored")
+		}
+	}
+}
+
+// observe, bağlantı kaynaklı hatalarda Handler'ı sağlıksız işaretler; böylece
+// sonraki istekler her seferinde zaman aşımını beklemez.
+func (h *Handler) observe(err error) {
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+	if h.healthy.Swap(false) {
+		zap.L().Warn("redis connection lost, cache bypassed", zap.Error(err))
+	}
+}
+
+func (h *Handler) addPending(pending map[string]struct{}, keys ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, key := range keys {
+		if len(pending) >= maxPending {
+			zap.L().Error("too many pending cache invalidations, dropping", zap.String("key", key))
+			continue
+		}
+		pending[key] = struct{}{}
+	}
+}
+
+func (h *Handler) flushPending(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.pendingDeletes) == 0 && len(h.pendingIncrs) == 0 {
+		return nil
+	}
+
+	pipe := h.client.Pipeline()
+	for key := range h.pendingDeletes {
+		pipe.Unlink(ctx, key)
+	}
+	for key := range h.pendingIncrs {
+		pipe.Incr(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	zap.L().Info("redis pending invalidations applied",
+		zap.Int("deletes", len(h.pendingDeletes)), zap.Int("incrs", len(h.pendingIncrs)))
+	clear(h.pendingDeletes)
+	clear(h.pendingIncrs)
+	return nil
+}
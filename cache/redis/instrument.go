@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("stackapi/cache/redis")
+
+var commandDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "cache_redis_command_duration_seconds",
+	Help:    "Redis komutlarının süresi; pipeline'lar command=pipeline olarak ölçülür.",
+	Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+}, []string{"command", "status"})
+
+// instrumentation, her Redis komutu için bir span açar ve süresini ölçer.
+// Span'ler isteğin context'indeki trace'e bağlandığı için gorm span'leriyle
+// aynı trace'te görünür. Health check ping'i gibi bir isteğe bağlı olmayan
+// komutlar için span açılmaz, yalnızca süre ölçülür.
+type instrumentation struct{}
+
+func (instrumentation) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (instrumentation) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		name := cmd.Name()
+		ctx, span := startSpan(ctx, "redis."+name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "redis"),
+				attribute.String("db.operation", name),
+			),
+		)
+		defer span.End()
+
+		start := time.Now()
+		err := next(ctx, cmd)
+		commandDuration.WithLabelValues(name, status(err)).Observe(time.Since(start).Seconds())
+		record(span, err)
+		return err
+	}
+}
+
+func (instrumentation) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		names := make([]string, len(cmds))
+		for i, cmd := range cmds {
+			names[i] = cmd.Name()
+		}
+		ctx, span := startSpan(ctx, "redis.pipeline",
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "redis"),
+				attribute.String("db.operation", strings.Join(names, " ")),
+				attribute.Int("db.redis.num_cmd", len(cmds)),
+			),
+		)
+		defer span.End()
+
+		start := time.Now()
+		err := next(ctx, cmds)
+		commandDuration.WithLabelValues("pipeline", status(err)).Observe(time.Since(start).Seconds())
+		record(span, err)
+		return err
+	}
+}
+
+func startSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return tracer.Start(ctx, name, opts...)
+}
+
+// redis.Nil bir hata değil, anahtarın olmadığını belirtir.
+func status(err error) string {
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return "error"
+	}
+	return "ok"
+}
+
+func record(span trace.Span, err error) {
+	if status(err) == "error" {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
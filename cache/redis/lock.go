@@ -0,0 +1,49 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// unlockScript, kilidi yalnızca hâlâ bu sahibe aitse siler; TTL dolup başka
+// bir replika kilidi aldıysa ona dokunmaz.
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Lock, SET NX PX ile dağıtık bir kilit almayı dener.
+func (h *Handler) Lock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	if !h.healthy.Load() {
+		return nil, false, ErrUnavailable
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, false, err
+	}
+	owner := hex.EncodeToString(token)
+
+	ok, err := h.client.SetNX(ctx, key, owner, ttl).Result()
+	if err != nil {
+		h.observe(err)
+		return nil, false, ErrLockFailed
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		if err := unlockScript.Run(context.Background(), h.client, []string{key}, owner).Err(); err != nil {
+			zap.L().Warn("redis unlock failed", zap.String("key", key), zap.Error(err))
+		}
+	}
+	return unlock, true, nil
+}
@@ -0,0 +1,17 @@
+package redis
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var valueSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "cache_value_size_bytes",
+	Help:    "Redis'e yazılan değerlerin kodlamadan önceki (raw) ve sonraki (encoded) boyutu.",
+	Buckets: prometheus.ExponentialBuckets(64, 4, 10),
+}, []string{"codec", "stage"})
+
+func observeSize(codec string, raw, encoded int) {
+	valueSize.WithLabelValues(codec, "raw").Observe(float64(raw))
+	valueSize.WithLabelValues(codec, "encoded").Observe(float64(encoded))
+}
@@ -0,0 +1,91 @@
+package redis
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+)
+
+const (
+	ModeStandalone = "standalone"
+	ModeSentinel   = "sentinel"
+	ModeCluster    = "cluster"
+)
+
+// options, redis.mode ayarına göre standalone, Sentinel veya Cluster
+// bağlantı seçeneklerini hazırlar.
+func options() (*redis.UniversalOptions, error) {
+	opt := &redis.UniversalOptions{
+		Username: viper.GetString("redis.user"),
+		Password: viper.GetString("redis.password"),
+	}
+
+	switch mode := viper.GetString("redis.mode"); mode {
+	case "", ModeStandalone:
+		opt.Addrs = []string{fmt.Sprintf("%s:%d", viper.GetString("redis.hostname"), viper.GetInt("redis.port"))}
+		opt.DB = viper.GetInt("redis.db")
+	case ModeSentinel:
+		opt.Addrs = viper.GetStringSlice("redis.addrs")
+		opt.MasterName = viper.GetString("redis.master_name")
+		opt.SentinelUsername = viper.GetString("redis.sentinel_user")
+		opt.SentinelPassword = viper.GetString("redis.sentinel_password")
+		opt.DB = viper.GetInt("redis.db")
+		if opt.MasterName == "" {
+			return nil, fmt.Errorf("%w: sentinel mode requires redis.master_name", ErrInvalidConfig)
+		}
+	case ModeCluster:
+		opt.Addrs = viper.GetStringSlice("redis.addrs")
+		opt.IsClusterMode = true
+	default:
+		return nil, fmt.Errorf("%w: unknown redis.mode %q", ErrInvalidConfig, mode)
+	}
+
+	if len(opt.Addrs) == 0 {
+		return nil, fmt.Errorf("%w: redis.addrs is empty", ErrInvalidConfig)
+	}
+
+	if viper.GetBool("redis.tls.enabled") {
+		tlsConfig, err := tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		opt.TLSConfig = tlsConfig
+	}
+
+	return opt, nil
+}
+
+func tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         viper.GetString("redis.tls.server_name"),
+		InsecureSkipVerify: viper.GetBool("redis.tls.insecure_skip_verify"),
+	}
+
+	if caFile := viper.GetString("redis.tls.ca_file"); caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: no certificates in %s", ErrInvalidConfig, caFile)
+		}
+		config.RootCAs = pool
+	}
+
+	certFile, keyFile := viper.GetString("redis.tls.cert_file"), viper.GetString("redis.tls.key_file")
+	if certFile != "" && keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
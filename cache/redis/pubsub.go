@@ -0,0 +1,36 @@
+package redis
+
+import (
+	"context"
+)
+
+func (h *Handler) Publish(ctx context.Context, channel string, message []byte) error {
+	if !h.healthy.Load() {
+		return ErrUnavailable
+	}
+	if err := h.client.Publish(ctx, channel, message).Err(); err != nil {
+		h.observe(err)
+		return ErrPublishFailed
+	}
+	return nil
+}
+
+// Subscribe, ctx iptal edilene kadar kanaldaki mesajları fn'e iletir.
+// Bağlantı koparsa go-redis aboneliği kendisi yeniden kurar.
+func (h *Handler) Subscribe(ctx context.Context, channel string, fn func(message []byte)) error {
+	pubsub := h.client.Subscribe(ctx, channel)
+	defer pubsub.Close()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			fn([]byte(msg.Payload))
+		}
+	}
+}
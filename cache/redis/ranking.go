@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Sayımlar saatlik kümelerde tutulur ve Top son rankWindows kümenin
+// toplamına bakar. Tek bir kümede skorlar hiç azalmadığı için küme dolunca
+// yeni bir eleman 1 skoruyla girip hemen atılırdı; saatlik kümeler boş
+// başladığından yeni aramalar da sıralamaya girebilir, eski popülerlik
+// rankWindows saat sonra düşer.
+const (
+	rankWindow  = time.Hour
+	rankWindows = 24
+)
+
+// rankKeys, t anındaki kümeden başlayarak son rankWindows kümenin
+// anahtarlarını döner. Anahtarlar cluster modunda aynı slot'a düşmesi için
+// hash tag ile üretilir.
+func rankKeys(key string, t time.Time) []string {
+	current := t.Unix() / int64(rankWindow/time.Second)
+	keys := make([]string, rankWindows)
+	for i := range keys {
+		keys[i] = "{" + key + "}:" + strconv.FormatInt(current-int64(i), 10)
+	}
+	return keys
+}
+
+func (h *Handler) Rank(ctx context.Context, key, member string, keep int) error {
+	if !h.healthy.Load() {
+		return ErrUnavailable
+	}
+	bucket := rankKeys(key, time.Now())[0]
+
+	pipe := h.client.TxPipeline()
+	pipe.ZIncrBy(ctx, bucket, 1, member)
+	if keep > 0 {
+		pipe.ZRemRangeByRank(ctx, bucket, 0, int64(-keep-1))
+	}
+	pipe.Expire(ctx, bucket, rankWindow*rankWindows)
+	if _, err := pipe.Exec(ctx); err != nil {
+		h.observe(err)
+		return ErrSetDataFailed
+	}
+	return nil
+}
+
+func (h *Handler) Top(ctx context.Context, key string, n int) ([]string, error) {
+	if !h.healthy.Load() {
+		return nil, ErrUnavailable
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+	scores, err := h.client.ZUnionWithScores(ctx, redis.ZStore{Keys: rankKeys(key, time.Now())}).Result()
+	if err != nil {
+		h.observe(err)
+		return nil, ErrGetDataFailed
+	}
+	// ZUNION skorları küçükten büyüğe döner.
+	slices.Reverse(scores)
+
+	members := make([]string, 0, min(n, len(scores)))
+	for _, z := range scores[:min(n, len(scores))] {
+		members = append(members, z.Member.(string))
+	}
+	return members, nil
+}
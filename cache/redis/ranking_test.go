@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRankKeys(t *testing.T) {
+	at := time.Date(2025, 3, 1, 10, 59, 59, 0, time.UTC)
+	keys := rankKeys("v1:actors:searches", at)
+
+	if len(keys) != rankWindows {
+		t.Fatalf("got %d keys, want %d", len(keys), rankWindows)
+	}
+	hour := at.Unix() / 3600
+	if want := "{v1:actors:searches}:" + strconv.FormatInt(hour, 10); keys[0] != want {
+		t.Fatalf("current bucket = %s, want %s", keys[0], want)
+	}
+	if want := "{v1:actors:searches}:" + strconv.FormatInt(hour-rankWindows+1, 10); keys[rankWindows-1] != want {
+		t.Fatalf("oldest bucket = %s, want %s", keys[rankWindows-1], want)
+	}
+
+	// Bir sonraki saatte yeni bir küme başlar ve en eski küme düşer.
+	next := rankKeys("v1:actors:searches", at.Add(time.Second))
+	if next[0] == keys[0] || next[1] != keys[0] || next[rankWindows-1] != keys[rankWindows-2] {
+		t.Fatalf("buckets did not roll over: %v -> %v", keys[:2], next[:2])
+	}
+	// Aynı saat içinde küme değişmez.
+	if same := rankKeys("v1:actors:searches", at.Add(-time.Hour+time.Second)); same[0] != keys[0] {
+		t.Fatalf("bucket changed within the hour: %s -> %s", keys[0], same[0])
+	}
+}
@@ -2,62 +2,147 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/EmreZURNACI/apistack/cache"
 	"github.com/redis/go-redis/v9"
 	"github.com/spf13/viper"
+	"go.uber.org/zap"
 )
 
 type Handler struct {
-	client *redis.Client
-}
+	client  redis.UniversalClient
+	healthy atomic.Bool
+	stop    context.CancelFunc
+
+	// Değerler codec ile kodlanıp başlık baytıyla yazılır; okurken başlığa
+	// göre çözülür.
+	header byte
+	codec  Codec
 
-type Message struct {
-	Key      []byte        `json:"key"`
-	Value    []byte        `json:"value"`
-	Duration time.Duration `json:"duration"`
+	// Redis erişilemezken yapılamayan invalidation'lar; bağlantı geri
+	// geldiğinde uygulanır.
+	mu             sync.Mutex
+	pendingDeletes map[string]struct{}
+	pendingIncrs   map[string]struct{}
 }
 
+// Connection, Redis'e erişilemese bile Handler döner. Bu durumda cache
+// işlemleri ErrUnavailable ile hemen döner ve bağlantı arka planda
+// redis.health_interval aralıklarla yeniden denenir.
 func Connection() (*Handler, error) {
 
-	var dsn string = fmt.Sprintf("redis://%s:%s@%s:%d/%d",
-		viper.GetString("redis.user"),
-		viper.GetString("redis.password"),
-		viper.GetString("redis.hostname"),
-		viper.GetInt("redis.port"),
-		viper.GetInt("redis.db"))
+	opt, err := options()
+	if err != nil {
+		return nil, err
+	}
 
-	opt, err := redis.ParseURL(dsn)
+	header, codec, err := codecByName(viper.GetString("redis.codec"))
 	if err != nil {
-		return nil, ErrURLParseFailed
+		return nil, err
 	}
 
-	client := redis.NewClient(opt)
+	ctx, stop := context.WithCancel(context.Background())
+	h := &Handler{
+		client:         redis.NewUniversalClient(opt),
+		stop:           stop,
+		header:         header,
+		codec:          codec,
+		pendingDeletes: make(map[string]struct{}),
+		pendingIncrs:   make(map[string]struct{}),
+	}
+	h.client.AddHook(instrumentation{})
+
+	if err := h.client.Ping(ctx).Err(); err != nil {
+		zap.L().Warn("redis unavailable, starting in degraded mode", zap.Error(err))
+	} else {
+		h.healthy.Store(true)
+	}
 
-	if err := client.Ping(context.Background()).Err(); err != nil {
-		return nil, ErrConnectionFailed
+	interval := viper.GetDuration("redis.health_interval")
+	if interval <= 0 {
+		interval = 5 * time.Second
 	}
+	go h.monitor(ctx, interval)
 
-	return &Handler{
-		client: client,
-	}, nil
+	return h, nil
 }
 
-func (h *Handler) Set(ctx context.Context, msg Message) error {
-	//Duration=0 forever
-	err := h.client.Set(ctx, string(msg.Key), string(msg.Value), msg.Duration).Err()
+func (h *Handler) Close() error {
+	h.stop()
+	return h.client.Close()
+}
+
+func (h *Handler) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if !h.healthy.Load() {
+		return ErrUnavailable
+	}
+	//ttl=0 forever
+	err := h.client.Set(ctx, key, encode(h.header, h.codec, value), ttl).Err()
 	if err != nil {
+		h.observe(err)
 		return ErrSetDataFailed
 	}
 	return nil
 }
 
 func (h *Handler) Get(ctx context.Context, key string) ([]byte, error) {
+	if !h.healthy.Load() {
+		return nil, ErrUnavailable
+	}
 
-	value, err := h.client.Get(ctx, key).Result()
+	value, err := h.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, cache.ErrMiss
+	}
 	if err != nil {
+		h.observe(err)
 		return nil, ErrGetDataFailed
 	}
-	return []byte(value), nil
+	value, err = decode(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetDataFailed, err)
+	}
+	return value, nil
+}
+
+func (h *Handler) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	if !h.healthy.Load() {
+		h.addPending(h.pendingDeletes, keys...)
+		return ErrUnavailable
+	}
+	// Cluster modunda anahtarlar farklı slot'larda olabileceği için tek bir
+	// komut yerine anahtar başına UNLINK gönderilir. UNLINK, değeri arka
+	// planda serbest bıraktığı için büyük değerlerde Redis'i bloklamaz.
+	pipe := h.client.Pipeline()
+	for _, key := range keys {
+		pipe.Unlink(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		h.observe(err)
+		h.addPending(h.pendingDeletes, keys...)
+		return ErrDeleteDataFailed
+	}
+	return nil
+}
+
+func (h *Handler) Incr(ctx context.Context, key string) (int64, error) {
+	if !h.healthy.Load() {
+		h.addPending(h.pendingIncrs, key)
+		return 0, ErrUnavailable
+	}
+	value, err := h.client.Incr(ctx, key).Result()
+	if err != nil {
+		h.observe(err)
+		h.addPending(h.pendingIncrs, key)
+		return 0, ErrIncrFailed
+	}
+	return value, nil
 }
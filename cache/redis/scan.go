@@ -0,0 +1,112 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// scanCount, SCAN'in her adımda taraması için Redis'e verilen ipucudur.
+// Komut anahtar uzayını parça parça dolaştığı için KEYS gibi Redis'i
+// bloklamaz.
+const scanCount = 500
+
+// ScanKeys, prefix ile başlayan anahtarları SCAN ile parça parça bulur ve
+// her parçayı fn'e verir. Cluster modunda tüm master'lar taranır. fn hata
+// dönerse tarama durur.
+func (h *Handler) ScanKeys(ctx context.Context, prefix string, fn func(keys []string) error) error {
+	if !h.healthy.Load() {
+		return ErrUnavailable
+	}
+
+	match := escapePattern(prefix) + "*"
+
+	// ForEachMaster master'ları paralel dolaşır; fn'in eşzamanlı
+	// çağrılmaması için çağrılar sıraya alınır.
+	var mu sync.Mutex
+	err := h.eachMaster(ctx, func(ctx context.Context, client redis.Cmdable) error {
+		iter := client.Scan(ctx, 0, match, scanCount).Iterator()
+		batch := make([]string, 0, scanCount)
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			err := fn(batch)
+			batch = batch[:0]
+			return err
+		}
+
+		for iter.Next(ctx) {
+			batch = append(batch, iter.Val())
+			if len(batch) == scanCount {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+		return flush()
+	})
+	if err != nil {
+		h.observe(err)
+		return err
+	}
+	return nil
+}
+
+// TTLs, anahtarların kalan sürelerini keys ile aynı sırada döner. Süresiz
+// anahtarlar için -1, bulunmayanlar için -2 döner.
+func (h *Handler) TTLs(ctx context.Context, keys ...string) ([]time.Duration, error) {
+	if !h.healthy.Load() {
+		return nil, ErrUnavailable
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	pipe := h.client.Pipeline()
+	cmds := make([]*redis.DurationCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.PTTL(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		h.observe(err)
+		return nil, ErrGetDataFailed
+	}
+
+	ttls := make([]time.Duration, len(keys))
+	for i, cmd := range cmds {
+		ttls[i] = cmd.Val()
+	}
+	return ttls, nil
+}
+
+func (h *Handler) eachMaster(ctx context.Context, fn func(ctx context.Context, client redis.Cmdable) error) error {
+	if cluster, ok := h.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+			return fn(ctx, client)
+		})
+	}
+	return fn(ctx, h.client)
+}
+
+// escapePattern, prefix'teki glob karakterlerinin SCAN MATCH'te harfiyen
+// eşleşmesini sağlar.
+func escapePattern(prefix string) string {
+	var b strings.Builder
+	for _, r := range prefix {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/EmreZURNACI/apistack/cache/lru"
+	"go.uber.org/zap"
+)
+
+// Broadcaster, replikalar arası mesajlaşma sağlayan cache'lerin (ör. Redis
+// pub/sub) implemente ettiği arayüzdür.
+type Broadcaster interface {
+	Publish(ctx context.Context, channel string, message []byte) error
+	// Subscribe, ctx iptal edilene kadar gelen mesajları fn'e iletir.
+	Subscribe(ctx context.Context, channel string, fn func(message []byte)) error
+}
+
+// Tiered, uzak bir cache'in (Redis) önünde süreç içi LRU katmanı tutar.
+// Delete ve Incr diğer replikalara da duyurulur; böylece bir replikadaki
+// yazma diğerlerindeki yerel kopyaları da siler. Set duyurulmaz; yerel
+// kopyaların bayatlığı localTTL ile sınırlıdır.
+type Tiered struct {
+	local    *lru.Cache
+	remote   Cache
+	localTTL time.Duration
+	channel  string
+	origin   string
+}
+
+type invalidation struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
+
+func NewTiered(local *lru.Cache, remote Cache, localTTL time.Duration, channel string) *Tiered {
+	origin := make([]byte, 8)
+	_, _ = rand.Read(origin)
+
+	return &Tiered{
+		local:    local,
+		remote:   remote,
+		localTTL: localTTL,
+		channel:  channel,
+		origin:   hex.EncodeToString(origin),
+	}
+}
+
+func (t *Tiered) Get(ctx context.Context, key string) ([]byte, error) {
+	if value, ok := t.local.Get(key); ok {
+		return value, nil
+	}
+
+	value, err := t.remote.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	t.local.Set(key, value, t.localTTL)
+	return value, nil
+}
+
+func (t *Tiered) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := t.remote.Set(ctx, key, value, ttl); err != nil {
+		t.local.Delete(key)
+		return err
+	}
+
+	localTTL := t.localTTL
+	if ttl > 0 && ttl < localTTL {
+		localTTL = ttl
+	}
+	t.local.Set(key, value, localTTL)
+	return nil
+}
+
+func (t *Tiered) Delete(ctx context.Context, keys ...string) error {
+	t.local.Delete(keys...)
+	err := t.remote.Delete(ctx, keys...)
+	t.publish(ctx, keys...)
+	return err
+}
+
+func (t *Tiered) Incr(ctx context.Context, key string) (int64, error) {
+	t.local.Delete(key)
+	value, err := t.remote.Incr(ctx, key)
+	t.publish(ctx, key)
+	return value, err
+}
+
+// Lock, uzak cache Locker ise ona devredilir; değilse kilit her zaman alınır.
+// Rank ve Top yerel katmanı kullanmaz; uzak cache sıralı kümeleri
+// desteklemiyorsa Rank yok sayılır, Top boş döner.
+func (t *Tiered) Rank(ctx context.Context, key, member string, keep int) error {
+	if ranking, ok := t.remote.(Ranking); ok {
+		return ranking.Rank(ctx, key, member, keep)
+	}
+	return nil
+}
+
+func (t *Tiered) Top(ctx context.Context, key string, n int) ([]string, error) {
+	if ranking, ok := t.remote.(Ranking); ok {
+		return ranking.Top(ctx, key, n)
+	}
+	return nil, nil
+}
+
+func (t *Tiered) Lock(ctx context.Context, key string, ttl time.Duration) (func(), bool, error) {
+	if locker, ok := t.remote.(Locker); ok {
+		return locker.Lock(ctx, key, ttl)
+	}
+	return func() {}, true, nil
+}
+
+// Listen, diğer replikaların duyurduğu anahtarları yerel katmandan siler.
+// ctx iptal edilene kadar bloklar.
+func (t *Tiered) Listen(ctx context.Context) error {
+	broadcaster, ok := t.remote.(Broadcaster)
+	if !ok {
+		<-ctx.Done()
+		return nil
+	}
+
+	return broadcaster.Subscribe(ctx, t.channel, func(message []byte) {
+		var msg invalidation
+		if err := json.Unmarshal(message, &msg); err != nil {
+			zap.L().Warn("invalid cache invalidation message", zap.Error(err))
+			return
+		}
+		if msg.Origin == t.origin {
+			return
+		}
+		t.local.Delete(msg.Keys...)
+	})
+}
+
+func (t *Tiered) publish(ctx context.Context, keys ...string) {
+	broadcaster, ok := t.remote.(Broadcaster)
+	if !ok || len(keys) == 0 {
+		return
+	}
+
+	message, err := json.Marshal(invalidation{Origin: t.origin, Keys: keys})
+	if err != nil {
+		return
+	}
+	if err := broadcaster.Publish(ctx, t.channel, message); err != nil {
+		zap.L().Warn("cache invalidation publish failed", zap.Strings("keys", keys), zap.Error(err))
+	}
+}
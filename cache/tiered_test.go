@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EmreZURNACI/apistack/cache/lru"
+)
+
+// bus, Redis pub/sub'ın süreç içi karşılığıdır; mesajları aboneler
+// arasında eşzamanlı dağıtır.
+type bus struct {
+	mu   sync.Mutex
+	subs map[string][]func([]byte)
+	// subscribed, her yeni abonelikte bir değer alır.
+	subscribed chan struct{}
+}
+
+func newBus() *bus {
+	return &bus{subs: make(map[string][]func([]byte)), subscribed: make(chan struct{}, 16)}
+}
+
+// broadcastCache, replikaların paylaştığı uzak cache'tir.
+type broadcastCache struct {
+	*fakeCache
+	bus *bus
+}
+
+func (c *broadcastCache) Publish(_ context.Context, channel string, message []byte) error {
+	c.bus.mu.Lock()
+	subs := append([]func([]byte){}, c.bus.subs[channel]...)
+	c.bus.mu.Unlock()
+	for _, fn := range subs {
+		fn(message)
+	}
+	return nil
+}
+
+func (c *broadcastCache) Subscribe(ctx context.Context, channel string, fn func(message []byte)) error {
+	c.bus.mu.Lock()
+	c.bus.subs[channel] = append(c.bus.subs[channel], fn)
+	c.bus.mu.Unlock()
+	c.bus.subscribed <- struct{}{}
+	<-ctx.Done()
+	return nil
+}
+
+// replicas, aynı uzak cache'i paylaşan ve invalidation mesajlarını dinleyen
+// n Tiered döner.
+func replicas(t *testing.T, n int, localTTL time.Duration) (*fakeCache, []*Tiered) {
+	t.Helper()
+
+	remote := &broadcastCache{fakeCache: newFakeCache(), bus: newBus()}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	tiers := make([]*Tiered, n)
+	for i := range tiers {
+		tiers[i] = NewTiered(lru.New(0, 0), remote, localTTL, "invalidate")
+		go tiers[i].Listen(ctx)
+		<-remote.bus.subscribed
+	}
+	return remote.fakeCache, tiers
+}
+
+func TestTieredInvalidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(ctx context.Context, writer *Tiered) error
+		want  string
+	}{
+		{
+			name:  "delete",
+			write: func(ctx context.Context, writer *Tiered) error { return writer.Delete(ctx, "k") },
+		},
+		{
+			name: "incr",
+			write: func(ctx context.Context, writer *Tiered) error {
+				_, err := writer.Incr(ctx, "k")
+				return err
+			},
+			want: "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			remote, tiers := replicas(t, 2, time.Minute)
+			writer, reader := tiers[0], tiers[1]
+
+			remote.Set(ctx, "k", []byte("old"), 0)
+			if got, _ := reader.Get(ctx, "k"); string(got) != "old" {
+				t.Fatalf("reader Get = %q, want old", got)
+			}
+			if _, ok := reader.local.Get("k"); !ok {
+				t.Fatal("reader did not keep a local copy")
+			}
+
+			if err := tt.write(ctx, writer); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			// fakeCache Incr'i uygulamaz; duyurulan değer doğrudan yazılır.
+			if tt.want != "" {
+				remote.Set(ctx, "k", []byte(tt.want), 0)
+			}
+
+			if _, ok := reader.local.Get("k"); ok {
+				t.Fatal("reader kept its local copy after the invalidation")
+			}
+			got, err := reader.Get(ctx, "k")
+			if tt.want == "" {
+				if err != ErrMiss {
+					t.Fatalf("reader Get after delete = %q, %v; want ErrMiss", got, err)
+				}
+				return
+			}
+			if string(got) != tt.want {
+				t.Fatalf("reader Get = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTieredSetIsNotBroadcast, Set'in duyurulmadığını ve diğer
+// replikalardaki yerel kopyaların localTTL dolana kadar okunduğunu
+// doğrular.
+func TestTieredSetIsNotBroadcast(t *testing.T) {
+	ctx := context.Background()
+	_, tiers := replicas(t, 2, 30*time.Millisecond)
+	writer, reader := tiers[0], tiers[1]
+
+	writer.Set(ctx, "k", []byte("old"), time.Minute)
+	reader.Get(ctx, "k")
+	writer.Set(ctx, "k", []byte("new"), time.Minute)
+
+	if got, _ := writer.Get(ctx, "k"); string(got) != "new" {
+		t.Fatalf("writer Get = %q, want new", got)
+	}
+	if got, _ := reader.Get(ctx, "k"); string(got) != "old" {
+		t.Fatalf("reader Get before local ttl = %q, want old", got)
+	}
+	time.Sleep(40 * time.Millisecond)
+	if got, _ := reader.Get(ctx, "k"); string(got) != "new" {
+		t.Fatalf("reader Get after local ttl = %q, want new", got)
+	}
+}
+
+// TestTieredLocalTTL, yerel kopyanın uzak TTL'den uzun tutulmadığını
+// doğrular.
+func TestTieredLocalTTL(t *testing.T) {
+	ctx := context.Background()
+	remote, tiers := replicas(t, 1, time.Minute)
+
+	tiers[0].Set(ctx, "k", []byte("v"), 20*time.Millisecond)
+	remote.Delete(ctx, "k")
+	if _, ok := tiers[0].local.Get("k"); !ok {
+		t.Fatal("Set did not keep a local copy")
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, err := tiers[0].Get(ctx, "k"); err != ErrMiss {
+		t.Fatalf("Get after remote ttl = %v, want ErrMiss", err)
+	}
+}
@@ -1,14 +1,18 @@
 package actor
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
+	"mime"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/EmreZURNACI/apistack/app/actor"
-	"github.com/EmreZURNACI/apistack/cache/redis"
+	"github.com/EmreZURNACI/apistack/cache"
+	"github.com/EmreZURNACI/apistack/controller/apierror"
+	"github.com/EmreZURNACI/apistack/controller/validation"
 	"github.com/EmreZURNACI/apistack/domain"
-	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
@@ -16,77 +20,119 @@ import (
 
 var tracer = otel.Tracer("stackapi")
 
-var validate = validator.New()
-
-func (h *ActorController) GetActors(c *fiber.Ctx) error {
+// apiVersion, cache anahtarlarının önekidir; yanıt formatı değiştiğinde
+// eski sürümün cache'lenmiş değerleri okunmaz.
+const apiVersion = "v1"
+
+// listQuery, liste sorgusunun parametreleridir. Cache anahtarı bu alanlardan
+// üretildiği için warm-up da aynı struct'ı kullanır.
+type listQuery struct {
+	Search  string `json:"search" query:"search" mapstructure:"search"`
+	Limit   int    `json:"limit" query:"limit" mapstructure:"limit" validate:"gte=0,required_with=Cursor"`
+	Offset  int    `json:"offset" query:"offset" mapstructure:"offset" validate:"gte=0,excluded_with=Cursor"`
+	OrderBy bool   `json:"order_by" query:"order_by" mapstructure:"order_by" validate:"excluded_with=Sort"`
+	Sort    string `json:"sort,omitempty" query:"sort" mapstructure:"sort"`
+	Cursor  string `json:"cursor,omitempty" query:"cursor" mapstructure:"-"`
+	Total   string `json:"total,omitempty" query:"total" mapstructure:"total" validate:"omitempty,oneof=exact estimate none"`
+
+	// SearchMode fuzzy ise arama benzerliğe göre yapılır; WithScore ile
+	// benzerlik puanı kayıtlarla döner.
+	SearchMode string `json:"search_mode,omitempty" query:"search_mode" mapstructure:"search_mode" validate:"omitempty,oneof=contains fuzzy"`
+	WithScore  bool   `json:"with_score,omitempty" query:"with_score" mapstructure:"with_score"`
+
+	FirstName       string `json:"first_name,omitempty" query:"first_name" mapstructure:"first_name"`
+	FirstNamePrefix string `json:"first_name_prefix,omitempty" query:"first_name_prefix" mapstructure:"first_name_prefix"`
+	LastName        string `json:"last_name,omitempty" query:"last_name" mapstructure:"last_name"`
+	LastNamePrefix  string `json:"last_name_prefix,omitempty" query:"last_name_prefix" mapstructure:"last_name_prefix"`
+	UpdatedSince    string `json:"updated_since,omitempty" query:"updated_since" mapstructure:"updated_since" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	UpdatedBefore   string `json:"updated_before,omitempty" query:"updated_before" mapstructure:"updated_before" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
 
-	type input struct {
-		Search  string `json:"search"`
-		Limit   int    `json:"limit"`
-		Offset  int    `json:"offset"`
-		OrderBy bool   `json:"order_by"`
+// filter, doğrulanmış query parametrelerinden liste filtresini üretir.
+func (q listQuery) filter() domain.ActorFilter {
+	since, _ := time.Parse(time.RFC3339, q.UpdatedSince)
+	before, _ := time.Parse(time.RFC3339, q.UpdatedBefore)
+	return domain.ActorFilter{
+		FirstName:       q.FirstName,
+		FirstNamePrefix: q.FirstNamePrefix,
+		LastName:        q.LastName,
+		LastNamePrefix:  q.LastNamePrefix,
+		UpdatedSince:    since,
+		UpdatedBefore:   before,
 	}
+}
 
-	var i input
+func (h *ActorController) GetActors(c *fiber.Ctx) error {
+
+	var i listQuery
 
 	if err := c.QueryParser(&i); err != nil {
 		zap.L().Error("Error parsing query", zap.Error(err))
-		return c.JSON(err.Error())
+		return apierror.Malformed(err)
+	}
+
+	if err := validation.Validate.Struct(&i); err != nil {
+		return err
 	}
 
 	ctx, span := tracer.Start(c.UserContext(), "Actors")
 	defer span.End()
 
-	key := fmt.Sprintf("actors:search=%s", i.Search)
-
-	actors, err := h.cache.Get(ctx, key)
+	// Yalnızca ilk sayfalar warm-up'a aday olur.
+	if i.Search != "" && i.Cursor == "" {
+		h.recordSearch(ctx, i)
+	}
 
-	if err == nil {
-		var actorList []domain.Actor
-		if err := json.Unmarshal(actors, &actorList); err != nil {
-			return c.Status(400).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
+	res, err := h.list(ctx, i)
+	if err != nil {
+		return err
+	}
 
-		return c.Status(200).JSON(fiber.Map{
-			"actors": actorList,
-		})
+	if link := links(c, res); link != "" {
+		c.Set(fiber.HeaderLink, link)
 	}
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Send(res)
+}
 
-	ActorsHandler := actor.NewGetActorsHandler(h.db)
-	res, err := ActorsHandler.Handle(ctx, &actor.GetActorsRequest{
-		Search:  i.Search,
-		Limit:   i.Limit,
-		Offset:  i.Offset,
-		OrderBy: i.OrderBy,
-	})
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+// list, sorgunun JSON yanıtını cache üzerinden döner. Varsayılanlar cache
+// anahtarı üretilmeden önce burada uygulanır; böylece istekler ve warm-up
+// aynı sorgu için aynı anahtarı kullanır.
+func (h *ActorController) list(ctx context.Context, i listQuery) ([]byte, error) {
+	if i.Total == "" {
+		i.Total = h.defaultTotal
 	}
 
-	bs, err := json.Marshal(res.Actors)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": err.Error(),
+	load := func(ctx context.Context) ([]byte, error) {
+		ActorsHandler := actor.NewGetActorsHandler(h.repository, h.cursors)
+		res, err := ActorsHandler.Handle(ctx, &actor.GetActorsRequest{
+			Search:     i.Search,
+			SearchMode: i.SearchMode,
+			WithScore:  i.WithScore,
+			Limit:      i.Limit,
+			Offset:     i.Offset,
+			OrderBy:    i.OrderBy,
+			Sort:       i.Sort,
+			Filter:     i.filter(),
+			Cursor:     i.Cursor,
+			Total:      i.Total,
 		})
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(res)
 	}
-	err = h.cache.Set(ctx, redis.Message{
-		Key:      []byte(key),
-		Value:    bs,
-		Duration: time.Minute * 3,
-	})
+
+	// Nesil okunamazsa cache atlanır.
+	key, err := h.listKey(ctx, i)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		cache.Bypass("get", listGenerationKey, err)
 	}
 
-	return c.Status(200).JSON(fiber.Map{
-		"actors": res.Actors,
-	})
+	if key == "" {
+		return load(ctx)
+	}
+	return h.actors.Load(ctx, key, load)
 }
 func (h *ActorController) GetActor(c *fiber.Ctx) error {
 	var id = c.Params("id")
@@ -96,25 +142,47 @@ func (h *ActorController) GetActor(c *fiber.Ctx) error {
 	}
 
 	i := input{ID: id}
-	if err := validate.Struct(&i); err != nil {
+	if err := validation.Validate.Struct(&i); err != nil {
 		zap.L().Error("Error getting actor id", zap.Error(err))
-		return c.JSON(err.Error())
+		return err
+	}
+
+	actorID, err := domain.ParseActorID(i.ID)
+	if err != nil {
+		return err
 	}
 
 	ctx, span := tracer.Start(c.UserContext(), "Actor")
 	defer span.End()
 
-	ActorHandler := actor.NewGetActorHandler(h.db)
-	res, err := ActorHandler.Handle(ctx, &actor.GetActorRequest{
-		ActorID: i.ID,
-	})
+	load := func(ctx context.Context) ([]byte, error) {
+		ActorHandler := actor.NewGetActorHandler(h.repository)
+		res, err := ActorHandler.Handle(ctx, &actor.GetActorRequest{
+			ActorID: i.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(res)
+	}
+
+	// Nesil okunamazsa cache atlanır.
+	var res []byte
+	key, err := h.actorKey(ctx, actorID)
+	if err != nil {
+		cache.Bypass("get", actorGenerationKey(actorID), err)
+		res, err = load(ctx)
+	} else {
+		res, err = h.actor.Load(ctx, key, load)
+	}
 
 	if err != nil {
 		zap.L().Error("Error getting actor", zap.Error(err))
-		return c.JSON(err.Error())
+		return err
 	}
 
-	return c.JSON(res)
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Send(res)
 }
 func (h *ActorController) CreateActor(c *fiber.Ctx) error {
 	type input struct {
@@ -125,19 +193,19 @@ func (h *ActorController) CreateActor(c *fiber.Ctx) error {
 	var i input
 	if err := c.BodyParser(&i); err != nil {
 		zap.L().Error("Error parsing actor", zap.Error(err))
-		return c.JSON(err.Error())
+		return apierror.Malformed(err)
 
 	}
 
-	if err := validate.Struct(&i); err != nil {
+	if err := validation.Validate.Struct(&i); err != nil {
 		zap.L().Error("Error validating", zap.Error(err))
-		return c.JSON(err.Error())
+		return err
 	}
 
 	ctx, span := tracer.Start(c.UserContext(), "CreateActor")
 	defer span.End()
 
-	CreateActorHandler := actor.NewCreateActorHandler(h.db)
+	CreateActorHandler := actor.NewCreateActorHandler(h.repository)
 
 	res, err := CreateActorHandler.Handle(ctx, &actor.CreateActorRequest{
 		FirstName: i.FirstName,
@@ -146,11 +214,13 @@ func (h *ActorController) CreateActor(c *fiber.Ctx) error {
 
 	if err != nil {
 		zap.L().Error("Error creating actor", zap.Error(err))
-		return c.JSON(err.Error())
-
+		return err
 	}
 
-	return c.JSON(res)
+	// Bu id için daha önce cache'lenmiş bir "bulunamadı" sonucu olabilir.
+	h.invalidate(ctx, strconv.FormatInt(res.ID, 10))
+
+	return c.Status(fiber.StatusCreated).JSON(res)
 }
 func (h *ActorController) UpdateActor(c *fiber.Ctx) error {
 	var id string = c.Params("id")
@@ -164,28 +234,28 @@ func (h *ActorController) UpdateActor(c *fiber.Ctx) error {
 	i := input{ID: id}
 	if err := c.BodyParser(&i); err != nil {
 		zap.L().Error("Error parsing actor", zap.Error(err))
-		return c.JSON(err.Error())
+		return apierror.Malformed(err)
 	}
 
-	if err := validate.Struct(&i); err != nil {
+	if err := validation.Validate.Struct(&i); err != nil {
 		zap.L().Error("Error validating", zap.Error(err))
-		return c.JSON(err.Error())
+		return err
 	}
 
 	ctx, span := tracer.Start(c.UserContext(), "UpdateActor")
 	defer span.End()
 
-	GetActorHandler := actor.NewGetActorHandler(h.db)
+	GetActorHandler := actor.NewGetActorHandler(h.repository)
 	_, err := GetActorHandler.Handle(ctx, &actor.GetActorRequest{
 		ActorID: i.ID,
 	})
 
 	if err != nil {
 		zap.L().Error("Error getting actor", zap.Error(err))
-		return c.JSON(err.Error())
+		return err
 	}
 
-	UpdateActorHandler := actor.NewUpdateActorHandler(h.db)
+	UpdateActorHandler := actor.NewUpdateActorHandler(h.repository)
 	res, err := UpdateActorHandler.Handle(ctx, &actor.UpdateActorRequest{
 		ID:        i.ID,
 		FirstName: i.FirstName,
@@ -194,9 +264,61 @@ func (h *ActorController) UpdateActor(c *fiber.Ctx) error {
 
 	if err != nil {
 		zap.L().Error("Error updating actor", zap.Error(err))
-		return c.JSON(err.Error())
+		return err
+	}
+
+	h.invalidate(ctx, i.ID)
+
+	return c.JSON(res)
+}
+
+// acceptPatch, PATCH için desteklenen içerik tipleridir (RFC 5789
+// Accept-Patch). application/json, merge patch olarak yorumlanır.
+var acceptPatch = strings.Join([]string{actor.PatchMerge, actor.PatchJSON}, ", ")
+
+func (h *ActorController) PatchActor(c *fiber.Ctx) error {
+	var id = c.Params("id")
+
+	type input struct {
+		ID string `json:"id" validate:"required,numeric"`
+	}
+
+	i := input{ID: id}
+	if err := validation.Validate.Struct(&i); err != nil {
+		zap.L().Error("Error validating", zap.Error(err))
+		return err
 	}
 
+	contentType := c.Get(fiber.HeaderContentType)
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+	}
+	switch contentType {
+	case actor.PatchMerge, actor.PatchJSON:
+	case fiber.MIMEApplicationJSON:
+		contentType = actor.PatchMerge
+	default:
+		c.Set("Accept-Patch", acceptPatch)
+		return fiber.ErrUnsupportedMediaType
+	}
+
+	ctx, span := tracer.Start(c.UserContext(), "PatchActor")
+	defer span.End()
+
+	PatchActorHandler := actor.NewPatchActorHandler(h.repository)
+	res, err := PatchActorHandler.Handle(ctx, &actor.PatchActorRequest{
+		ID:          i.ID,
+		ContentType: contentType,
+		Patch:       c.Body(),
+	})
+
+	if err != nil {
+		zap.L().Error("Error patching actor", zap.Error(err))
+		return err
+	}
+
+	h.invalidate(ctx, i.ID)
+
 	return c.JSON(res)
 }
 func (h *ActorController) DeleteActor(c *fiber.Ctx) error {
@@ -207,22 +329,24 @@ func (h *ActorController) DeleteActor(c *fiber.Ctx) error {
 	}
 
 	i := input{ID: id}
-	if err := validate.Struct(&i); err != nil {
+	if err := validation.Validate.Struct(&i); err != nil {
 		zap.L().Error("Error getting actor id", zap.Error(err))
-		return c.JSON(err.Error())
+		return err
 	}
 
 	ctx, span := tracer.Start(c.UserContext(), "DeleteActor")
 	defer span.End()
 
-	DeleteActorHandler := actor.NewDeleteActorHandler(h.db)
+	DeleteActorHandler := actor.NewDeleteActorHandler(h.repository)
 	res, err := DeleteActorHandler.Handle(ctx, &actor.DeleteActorRequest{
 		ID: i.ID,
 	})
 	if err != nil {
 		zap.L().Error("Error deleting actor", zap.Error(err))
-		return c.JSON(err.Error())
+		return err
 	}
 
+	h.invalidate(ctx, i.ID)
+
 	return c.JSON(res)
 }
@@ -0,0 +1,291 @@
+package actor_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/EmreZURNACI/apistack/app/actor"
+	"github.com/EmreZURNACI/apistack/cache"
+	controller "github.com/EmreZURNACI/apistack/controller/actor"
+	"github.com/EmreZURNACI/apistack/controller/apierror"
+	"github.com/EmreZURNACI/apistack/domain"
+	"github.com/EmreZURNACI/apistack/infra/memory"
+	"github.com/EmreZURNACI/apistack/infra/postgresql/postgrestest"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+)
+
+func TestCreateActorConcurrentMemory(t *testing.T) {
+	testCreateActorConcurrent(t, memory.GetMemoryHandler(otel.Tracer("test")))
+}
+
+func TestCreateActorConcurrentPostgres(t *testing.T) {
+	handler, truncate := postgrestest.Open(t)
+	truncate(t)
+	testCreateActorConcurrent(t, handler)
+}
+
+// testCreateActorConcurrent, eşzamanlı POST isteklerinin her birinin farklı
+// bir id ile oluşturulduğunu doğrular.
+func testCreateActorConcurrent(t *testing.T, repo actor.Repository) {
+	const n = 1000
+
+	app := fiber.New(fiber.Config{ErrorHandler: apierror.Handler})
+	app.Post("/v1/actors", controller.NewActorController(repo, newMapCache()).CreateActor)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		ids  = make(map[int64]int, n)
+		errs []string
+	)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			body := fmt.Sprintf(`{"FirstName":"Actor","LastName":"N%d"}`, i)
+			req := httptest.NewRequest(fiber.MethodPost, "/v1/actors", strings.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+			res, err := app.Test(req, -1)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, err.Error())
+				mu.Unlock()
+				return
+			}
+			defer res.Body.Close()
+
+			var created actor.CreateActorResponse
+			decodeErr := json.NewDecoder(res.Body).Decode(&created)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if res.StatusCode != fiber.StatusCreated || decodeErr != nil {
+				errs = append(errs, fmt.Sprintf("request %d: status %d, decode %v", i, res.StatusCode, decodeErr))
+				return
+			}
+			ids[created.ID]++
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		t.Fatalf("%d of %d requests failed, first: %s", len(errs), n, errs[0])
+	}
+	if len(ids) != n {
+		t.Fatalf("got %d distinct ids for %d requests", len(ids), n)
+	}
+	for id, count := range ids {
+		if id <= 0 || count != 1 {
+			t.Fatalf("id %d returned %d times", id, count)
+		}
+	}
+}
+
+// mapCache, testler için süreç içi cache.Cache implementasyonudur; TTL'leri
+// yok sayar.
+type mapCache struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func newMapCache() *mapCache {
+	return &mapCache{values: make(map[string][]byte)}
+}
+
+func (c *mapCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.values[key]
+	if !ok {
+		return nil, cache.ErrMiss
+	}
+	return value, nil
+}
+
+func (c *mapCache) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+	return nil
+}
+
+func (c *mapCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.values, key)
+	}
+	return nil
+}
+
+func (c *mapCache) Incr(_ context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var n int64
+	fmt.Sscan(string(c.values[key]), &n)
+	n++
+	c.values[key] = []byte(fmt.Sprint(n))
+	return n, nil
+}
+
+// TestWarmUpServesFirstRequest, warm-up'ın doldurduğu anahtarın varsayılan
+// liste isteği tarafından okunduğunu doğrular.
+func TestWarmUpServesFirstRequest(t *testing.T) {
+	repo := &countingRepository{Repository: memory.GetMemoryHandler(otel.Tracer("test"))}
+	if _, err := repo.CreateActor(context.Background(), "Penelope", "Guiness"); err != nil {
+		t.Fatalf("CreateActor: %v", err)
+	}
+
+	h := controller.NewActorController(repo, newMapCache())
+	if err := h.WarmUp(context.Background()); err != nil {
+		t.Fatalf("WarmUp: %v", err)
+	}
+	warmed := repo.gets.Load()
+	if warmed == 0 {
+		t.Fatal("WarmUp did not query the repository")
+	}
+
+	app := fiber.New(fiber.Config{ErrorHandler: apierror.Handler})
+	app.Get("/v1/actors", h.GetActors)
+	res, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/v1/actors", nil), -1)
+	if err != nil {
+		t.Fatalf("GET /v1/actors: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != fiber.StatusOK {
+		t.Fatalf("GET /v1/actors status = %d, want %d", res.StatusCode, fiber.StatusOK)
+	}
+	if got := repo.gets.Load(); got != warmed {
+		t.Fatalf("GET /v1/actors queried the repository after warm-up (%d calls, want %d)", got, warmed)
+	}
+}
+
+type countingRepository struct {
+	actor.Repository
+	gets atomic.Int64
+}
+
+func (r *countingRepository) GetActors(ctx context.Context, query domain.ActorQuery) ([]domain.Actor, error) {
+	r.gets.Add(1)
+	return r.Repository.GetActors(ctx, query)
+}
+
+// TestPatchActorTestAfterConcurrentWrite, JSON Patch test işlemi okunan
+// kayda karşı geçtikten sonra araya giren yazmanın patch'i durdurduğunu
+// doğrular.
+func TestPatchActorTestAfterConcurrentWrite(t *testing.T) {
+	repo := &interleavingRepository{Repository: memory.GetMemoryHandler(otel.Tracer("test"))}
+	id, err := repo.CreateActor(context.Background(), "Penelope", "Guiness")
+	if err != nil {
+		t.Fatalf("CreateActor: %v", err)
+	}
+	actorID := fmt.Sprint(id)
+
+	// GetActor okunan kaydı döndükten sonra başka bir istek soyadı değiştirir.
+	repo.afterGet = func() {
+		time.Sleep(2 * time.Millisecond)
+		if err := repo.UpdateActor(context.Background(), actorID, "Penelope", "Cruz"); err != nil {
+			t.Errorf("UpdateActor: %v", err)
+		}
+	}
+
+	app := fiber.New(fiber.Config{ErrorHandler: apierror.Handler})
+	app.Patch("/v1/actors/:id", controller.NewActorController(repo, newMapCache()).PatchActor)
+
+	body := `[{"op":"test","path":"/LastName","value":"Guiness"},{"op":"replace","path":"/FirstName","value":"Nick"}]`
+	req := httptest.NewRequest(fiber.MethodPatch, "/v1/actors/"+actorID, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, actor.PatchJSON)
+	res, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("PATCH: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != fiber.StatusConflict {
+		t.Fatalf("PATCH status = %d, want %d", res.StatusCode, fiber.StatusConflict)
+	}
+
+	got, err := repo.GetActor(context.Background(), actorID)
+	if err != nil {
+		t.Fatalf("GetActor: %v", err)
+	}
+	if got.FirstName != "Penelope" || got.LastName != "Cruz" {
+		t.Fatalf("actor after failed PATCH = %s %s, want Penelope Cruz", got.FirstName, got.LastName)
+	}
+}
+
+type interleavingRepository struct {
+	actor.Repository
+	afterGet func()
+}
+
+func (r *interleavingRepository) GetActor(ctx context.Context, id string) (*domain.Actor, error) {
+	a, err := r.Repository.GetActor(ctx, id)
+	if hook := r.afterGet; hook != nil {
+		r.afterGet = nil
+		hook()
+	}
+	return a, err
+}
+
+// TestGetActorStaleLoadAfterUpdate, güncellemeden önce veritabanından okuyan
+// bir isteğin eski kaydı cache'e yazsa bile sonraki okumaların güncel kaydı
+// döndüğünü doğrular.
+func TestGetActorStaleLoadAfterUpdate(t *testing.T) {
+	repo := &interleavingRepository{Repository: memory.GetMemoryHandler(otel.Tracer("test"))}
+	id, err := repo.CreateActor(context.Background(), "Penelope", "Guiness")
+	if err != nil {
+		t.Fatalf("CreateActor: %v", err)
+	}
+	actorID := fmt.Sprint(id)
+
+	app := fiber.New(fiber.Config{ErrorHandler: apierror.Handler})
+	h := controller.NewActorController(repo, newMapCache())
+	app.Get("/v1/actors/:id", h.GetActor)
+	app.Put("/v1/actors/:id", h.UpdateActor)
+
+	// GET eski kaydı okuduktan sonra, cache'e yazmadan önce güncelleme
+	// tamamlanır.
+	repo.afterGet = func() {
+		body := `{"FirstName":"Penelope","LastName":"Cruz"}`
+		req := httptest.NewRequest(fiber.MethodPut, "/v1/actors/"+actorID, strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, err := app.Test(req, -1)
+		if err != nil {
+			t.Errorf("PUT: %v", err)
+			return
+		}
+		res.Body.Close()
+		if res.StatusCode != fiber.StatusOK {
+			t.Errorf("PUT status = %d, want %d", res.StatusCode, fiber.StatusOK)
+		}
+	}
+
+	get := func() domain.Actor {
+		t.Helper()
+		res, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/v1/actors/"+actorID, nil), -1)
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		defer res.Body.Close()
+		var got actor.GetActorResponse
+		if err := json.NewDecoder(res.Body).Decode(&got); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return got.Actor
+	}
+
+	get()
+	if got := get(); got.LastName != "Cruz" {
+		t.Fatalf("GET after update = %s %s, want Penelope Cruz", got.FirstName, got.LastName)
+	}
+}
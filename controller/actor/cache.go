@@ -0,0 +1,75 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/EmreZURNACI/apistack/cache"
+	"github.com/EmreZURNACI/apistack/domain"
+)
+
+// Liste yanıtları, anahtarında güncel nesil numarası bulunan kayıtlarda
+// tutulur. Her yazma işlemi nesli artırır; böylece eski listeler tek tek
+// silinmeden erişilemez hale gelir ve TTL dolunca Redis'ten düşer.
+var listGenerationKey = cache.Key(apiVersion, "actors", "list", "generation")
+
+// listFormat, liste yanıtının cache'teki biçimidir. Yanıt zarfı
+// değiştiğinde artırılır; böylece eski biçimdeki kayıtlar okunmaz.
+const listFormat = "f2"
+
+// listKey, sorgu parametreleri ve güncel liste nesliyle anahtar üretir.
+func (h *ActorController) listKey(ctx context.Context, params any) (string, error) {
+	generation := "0"
+
+	value, err := h.cache.Get(ctx, listGenerationKey)
+	switch {
+	case err == nil:
+		generation = string(value)
+	case !errors.Is(err, cache.ErrMiss):
+		return "", err
+	}
+
+	return cache.Key(apiVersion, "actors", "list", listFormat, "g"+generation, cache.Hash(cache.Params(params))), nil
+}
+
+// actorGenerationKey, aktörün kendi nesil sayacıdır. Aktör kayıtları da
+// listeler gibi nesille anahtarlanır: güncellemeden önce veritabanından
+// okuyan bir istek eski kaydı ancak artık okunmayan eski nesle yazabilir.
+// Anahtarı silmek bunu engellemez; silmeden sonra yazılan eski kayıt
+// actor_ttl boyunca dönerdi.
+func actorGenerationKey(id int64) string {
+	return cache.Key(apiVersion, "actors", strconv.FormatInt(id, 10), "generation")
+}
+
+// actorKey, aktörün güncel nesliyle anahtar üretir.
+func (h *ActorController) actorKey(ctx context.Context, id int64) (string, error) {
+	generation := "0"
+
+	value, err := h.cache.Get(ctx, actorGenerationKey(id))
+	switch {
+	case err == nil:
+		generation = string(value)
+	case !errors.Is(err, cache.ErrMiss):
+		return "", err
+	}
+
+	return cache.Key(apiVersion, "actors", strconv.FormatInt(id, 10), "g"+generation), nil
+}
+
+// invalidate, yazma işleminden sonra listelerin ve verilen aktörlerin
+// neslini artırır. Yazma zaten tamamlandığı için hata yalnızca loglanır;
+// Redis'e ulaşılamıyorsa invalidation bağlantı geri geldiğinde uygulanır.
+func (h *ActorController) invalidate(ctx context.Context, ids ...string) {
+	keys := []string{listGenerationKey}
+	for _, id := range ids {
+		if actorID, err := domain.ParseActorID(id); err == nil {
+			keys = append(keys, actorGenerationKey(actorID))
+		}
+	}
+	for _, key := range keys {
+		if _, err := h.cache.Incr(ctx, key); err != nil {
+			cache.Bypass("invalidate", key, err)
+		}
+	}
+}
@@ -1,18 +1,71 @@
 package actor
 
 import (
-	"github.com/EmreZURNACI/apistack/cache/redis"
-	"github.com/EmreZURNACI/apistack/infra/postgresql"
+	"time"
+
+	"github.com/EmreZURNACI/apistack/app/actor"
+	"github.com/EmreZURNACI/apistack/cache"
+	"github.com/EmreZURNACI/apistack/domain"
+	"github.com/spf13/viper"
 )
 
 type ActorController struct {
-	cache *redis.Handler
-	db    *postgresql.PostgresHandler
+	cache   cache.Cache
+	actor   *cache.Loader
+	actors  *cache.Loader
+	ranking cache.Ranking
+	cursors *actor.Cursors
+	// defaultTotal, total parametresi verilmediğinde kullanılır.
+	defaultTotal string
+	repository   actor.Repository
 }
 
-func NewActorController(db *postgresql.PostgresHandler, cache *redis.Handler) *ActorController {
+func NewActorController(repository actor.Repository, c cache.Cache) *ActorController {
+	staleTTL := viper.GetDuration("cache.stale_ttl")
+	lockTTL := durationOr("cache.lock_ttl", 5*time.Second)
+
+	ranking, _ := c.(cache.Ranking)
+
+	// Anahtar yalnızca ortamdan okunur ve boş olmaması server.Route'ta
+	// denetlenir; imleçli yanıtlar paylaşılan cache'e yazıldığı için tüm
+	// replikalarda aynı olmalıdır.
+	cursors := actor.NewCursors([]byte(viper.GetString("pagination.cursor_secret")), durationOr("pagination.cursor_ttl", 24*time.Hour))
+
 	return &ActorController{
-		cache: cache,
-		db:    db,
+		cache:        c,
+		ranking:      ranking,
+		cursors:      cursors,
+		defaultTotal: defaultTotal(),
+		actor: cache.NewLoader(c, cache.LoaderConfig{
+			Prefix:      cache.Key(apiVersion, "actors"),
+			TTL:         durationOr("cache.actor_ttl", 5*time.Minute),
+			StaleTTL:    staleTTL,
+			Negative:    domain.ErrActorNotFound,
+			NegativeTTL: durationOr("cache.actor_not_found_ttl", 30*time.Second),
+			LockTTL:     lockTTL,
+		}),
+		actors: cache.NewLoader(c, cache.LoaderConfig{
+			Prefix:   cache.Key(apiVersion, "actors", "list"),
+			TTL:      durationOr("cache.list_ttl", 3*time.Minute),
+			StaleTTL: staleTTL,
+			LockTTL:  lockTTL,
+		}),
+		repository: repository,
+	}
+}
+
+func durationOr(key string, fallback time.Duration) time.Duration {
+	if d := viper.GetDuration(key); d > 0 {
+		return d
+	}
+	return fallback
+}
+
+func defaultTotal() string {
+	switch total := viper.GetString("pagination.total"); total {
+	case actor.TotalExact, actor.TotalEstimate, actor.TotalNone:
+		return total
+	default:
+		return actor.TotalExact
 	}
 }
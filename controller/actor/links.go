@@ -0,0 +1,89 @@
+package actor
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// page, Link başlığı için liste yanıtından okunan alanlardır.
+type page struct {
+	Items      []json.RawMessage `json:"items"`
+	Total      *int64            `json:"total"`
+	Limit      int               `json:"limit"`
+	Offset     int               `json:"offset"`
+	NextCursor string            `json:"next_cursor"`
+	PrevCursor string            `json:"prev_cursor"`
+}
+
+// links, liste yanıtı için RFC 8288 Link başlığını üretir. Keyset modunda
+// imleçlerle next/prev, offset modunda offset ile first/prev/next ve toplam
+// biliniyorsa last bağlantıları verilir.
+func links(c *fiber.Ctx, body []byte) string {
+	var p page
+	if err := json.Unmarshal(body, &p); err != nil || p.Limit <= 0 {
+		return ""
+	}
+
+	base := c.BaseURL() + c.Path()
+	query, _ := url.ParseQuery(string(c.Request().URI().QueryString()))
+
+	var out []string
+	link := func(rel string, set func(q url.Values)) {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		set(q)
+		out = append(out, "<"+base+"?"+q.Encode()+`>; rel="`+rel+`"`)
+	}
+	withCursor := func(cursor string) func(q url.Values) {
+		return func(q url.Values) {
+			q.Del("offset")
+			q.Set("cursor", cursor)
+		}
+	}
+	withOffset := func(offset int) func(q url.Values) {
+		return func(q url.Values) {
+			q.Del("cursor")
+			q.Set("offset", strconv.Itoa(offset))
+		}
+	}
+
+	if p.NextCursor != "" || p.PrevCursor != "" {
+		if p.NextCursor != "" {
+			link("next", withCursor(p.NextCursor))
+		}
+		if p.PrevCursor != "" {
+			link("prev", withCursor(p.PrevCursor))
+		}
+		return strings.Join(out, ", ")
+	}
+
+	if query.Has("cursor") {
+		return ""
+	}
+
+	link("first", withOffset(0))
+	if p.Offset > 0 {
+		link("prev", withOffset(max(p.Offset-p.Limit, 0)))
+	}
+	if p.Total == nil {
+		// Toplam bilinmiyorsa dolu sayfa sonraki sayfanın varlığına işaret
+		// sayılır.
+		if len(p.Items) == p.Limit {
+			link("next", withOffset(p.Offset+p.Limit))
+		}
+	} else {
+		if int64(p.Offset+p.Limit) < *p.Total {
+			link("next", withOffset(p.Offset+p.Limit))
+		}
+		if *p.Total > 0 {
+			link("last", withOffset(int((*p.Total-1)/int64(p.Limit))*p.Limit))
+		}
+	}
+	return strings.Join(out, ", ")
+}
@@ -0,0 +1,78 @@
+package actor
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/EmreZURNACI/apistack/cache"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// searchesKey, arama içeren liste sorgularının ne sıklıkla yapıldığını
+// tutan sıralı kümedir. Elemanlar sorgunun JSON halidir; warm-up aynı
+// parametrelerle aynı cache anahtarını üretir.
+var searchesKey = cache.Key(apiVersion, "actors", "searches")
+
+// maxRecordedSearches, her zaman penceresinde tutulan en fazla farklı sorgu
+// sayısıdır.
+const maxRecordedSearches = 1000
+
+func (h *ActorController) recordSearch(ctx context.Context, q listQuery) {
+	if h.ranking == nil {
+		return
+	}
+	member, err := json.Marshal(q)
+	if err != nil {
+		return
+	}
+	if err := h.ranking.Rank(ctx, searchesKey, string(member), maxRecordedSearches); err != nil {
+		cache.Bypass("rank", searchesKey, err)
+	}
+}
+
+// WarmUp, cache.warmup.queries ile verilen sorguları ve en sık yapılan
+// cache.warmup.top_searches aramayı önceden cache'e yükler. Sorgu listesi
+// verilmemişse varsayılan liste sayfası yüklenir. Tek bir sorgunun hatası
+// diğerlerini durdurmaz.
+func (h *ActorController) WarmUp(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "WarmUp")
+	defer span.End()
+
+	queries := []listQuery{{}}
+	if viper.IsSet("cache.warmup.queries") {
+		queries = nil
+		if err := viper.UnmarshalKey("cache.warmup.queries", &queries); err != nil {
+			return err
+		}
+	}
+
+	if n := viper.GetInt("cache.warmup.top_searches"); n > 0 && h.ranking != nil {
+		members, err := h.ranking.Top(ctx, searchesKey, n)
+		if err != nil {
+			cache.Bypass("top", searchesKey, err)
+		}
+		for _, member := range members {
+			var q listQuery
+			if err := json.Unmarshal([]byte(member), &q); err != nil {
+				continue
+			}
+			queries = append(queries, q)
+		}
+	}
+
+	warmed := 0
+	for _, q := range queries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := h.list(ctx, q); err != nil {
+			zap.L().Warn("cache warm-up query failed", zap.Any("query", q), zap.Error(err))
+			continue
+		}
+		warmed++
+	}
+
+	zap.L().Info("cache warmed up", zap.Int("queries", warmed), zap.Int("total", len(queries)))
+	return nil
+}
@@ -0,0 +1,44 @@
+package admin_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/EmreZURNACI/apistack/controller/admin"
+	"github.com/EmreZURNACI/apistack/controller/apierror"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAdminRoutes(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: apierror.Handler})
+	// İstekler handler'a ulaşmadan reddedildiği için depo gerekmez.
+	h := admin.NewAdminController(nil, nil)
+	app.Post("/admin/cache/flush", admin.Auth("secret"), h.FlushCache)
+
+	tests := []struct {
+		name   string
+		auth   string
+		target string
+		want   int
+	}{
+		{"missing token", "", "/admin/cache/flush?prefix=v1:", fiber.StatusUnauthorized},
+		{"wrong token", "Bearer nope", "/admin/cache/flush?prefix=v1:", fiber.StatusUnauthorized},
+		{"empty prefix", "Bearer secret", "/admin/cache/flush", fiber.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodPost, tt.target, nil)
+			if tt.auth != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.auth)
+			}
+			res, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatalf("POST %s: %v", tt.target, err)
+			}
+			res.Body.Close()
+			if res.StatusCode != tt.want {
+				t.Fatalf("status = %d, want %d", res.StatusCode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"net/url"
+
+	"github.com/EmreZURNACI/apistack/app/admin"
+	"github.com/EmreZURNACI/apistack/controller/apierror"
+	"github.com/EmreZURNACI/apistack/controller/validation"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+)
+
+var tracer = otel.Tracer("stackapi")
+
+func (h *AdminController) ListCacheKeys(c *fiber.Ctx) error {
+	type input struct {
+		Prefix string `json:"prefix" query:"prefix"`
+		Limit  int    `json:"limit" query:"limit" validate:"gte=0,lte=1000"`
+	}
+
+	var i input
+	if err := c.QueryParser(&i); err != nil {
+		zap.L().Error("Error parsing query", zap.Error(err))
+		return apierror.Malformed(err)
+	}
+	if err := validation.Validate.Struct(&i); err != nil {
+		return err
+	}
+
+	ctx, span := tracer.Start(c.UserContext(), "ListCacheKeys")
+	defer span.End()
+
+	res, err := admin.NewListCacheKeysHandler(h.keys).Handle(ctx, &admin.ListCacheKeysRequest{
+		Prefix: i.Prefix,
+		Limit:  i.Limit,
+	})
+	if err != nil {
+		zap.L().Error("Error listing cache keys", zap.Error(err))
+		return err
+	}
+	return c.JSON(res)
+}
+
+func (h *AdminController) DeleteCacheKey(c *fiber.Ctx) error {
+	key, err := url.PathUnescape(c.Params("key"))
+	if err != nil {
+		return apierror.Malformed(err)
+	}
+
+	ctx, span := tracer.Start(c.UserContext(), "DeleteCacheKey")
+	defer span.End()
+
+	res, err := admin.NewDeleteCacheKeyHandler(h.keys, h.cache).Handle(ctx, &admin.DeleteCacheKeyRequest{
+		Key: key,
+	})
+	if err != nil {
+		zap.L().Error("Error deleting cache key", zap.String("key", key), zap.Error(err))
+		return err
+	}
+	zap.L().Info("cache key deleted", zap.String("key", key))
+	return c.JSON(res)
+}
+
+func (h *AdminController) FlushCache(c *fiber.Ctx) error {
+	// Boş prefix tüm cache'i sileceği için kabul edilmez.
+	type input struct {
+		Prefix string `json:"prefix" query:"prefix" validate:"required"`
+	}
+
+	var i input
+	if err := c.QueryParser(&i); err != nil {
+		zap.L().Error("Error parsing query", zap.Error(err))
+		return apierror.Malformed(err)
+	}
+	if err := validation.Validate.Struct(&i); err != nil {
+		return err
+	}
+
+	ctx, span := tracer.Start(c.UserContext(), "FlushCache")
+	defer span.End()
+
+	res, err := admin.NewFlushCacheHandler(h.keys, h.cache).Handle(ctx, &admin.FlushCacheRequest{
+		Prefix: i.Prefix,
+	})
+	if err != nil {
+		zap.L().Error("Error flushing cache", zap.String("prefix", i.Prefix), zap.Error(err))
+		return err
+	}
+	zap.L().Info("cache flushed", zap.String("prefix", i.Prefix), zap.Int("deleted", res.Deleted))
+	return c.JSON(res)
+}
@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"crypto/subtle"
+
+	"github.com/EmreZURNACI/apistack/app/admin"
+	"github.com/EmreZURNACI/apistack/cache"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/keyauth"
+)
+
+type AdminController struct {
+	keys  admin.KeyStore
+	cache cache.Cache
+}
+
+func NewAdminController(keys admin.KeyStore, c cache.Cache) *AdminController {
+	return &AdminController{
+		keys:  keys,
+		cache: c,
+	}
+}
+
+// Auth, "Authorization: Bearer <token>" başlığını token ile karşılaştırır.
+// Hatalı veya eksik token 401 problem yanıtı döner.
+func Auth(token string) fiber.Handler {
+	return keyauth.New(keyauth.Config{
+		KeyLookup:  "header:" + fiber.HeaderAuthorization,
+		AuthScheme: "Bearer",
+		Validator: func(c *fiber.Ctx, key string) (bool, error) {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1 {
+				return true, nil
+			}
+			return false, keyauth.ErrMissingOrMalformedAPIKey
+		},
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return fiber.ErrUnauthorized
+		},
+	})
+}
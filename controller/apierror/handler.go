@@ -0,0 +1,140 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/EmreZURNACI/apistack/domain"
+	"github.com/EmreZURNACI/apistack/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"go.uber.org/zap"
+)
+
+const ContentType = "application/problem+json"
+
+// ErrMalformedRequest, istek gövdesi veya query parametreleri okunamadığında döner.
+var ErrMalformedRequest = errors.New("malformed request")
+
+// Malformed, BodyParser/QueryParser hatalarını sebebini kaybetmeden sarar.
+func Malformed(err error) error {
+	return fmt.Errorf("%w: %w", ErrMalformedRequest, err)
+}
+
+// Problem, RFC 7807 problem detayıdır. Code alanı istemcilerin
+// karşılaştırma yapabileceği sabit hata kodudur.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Handler, fiber.Config.ErrorHandler olarak kullanılır ve controllerlardan
+// dönen hataları application/problem+json yanıtlarına çevirir.
+func Handler(c *fiber.Ctx, err error) error {
+	p := resolve(err, i18n.FromContext(c.UserContext()))
+	p.Type = "/problems/" + strings.ReplaceAll(p.Code, "_", "-")
+	p.Title = utils.StatusMessage(p.Status)
+	p.Instance = c.OriginalURL()
+
+	if p.Status >= fiber.StatusInternalServerError {
+		zap.L().Error("request failed", zap.String("path", c.Path()), zap.Error(err))
+	}
+	return c.Status(p.Status).JSON(p, ContentType)
+}
+
+func resolve(err error, lang string) Problem {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return Problem{
+			Status: statusOf(domainErr),
+			Code:   domainErr.Code,
+			Detail: message(lang, domainErr.Code, domainErr.Message),
+		}
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		p := Problem{
+			Status: fiber.StatusUnprocessableEntity,
+			Code:   "validation_failed",
+			Detail: message(lang, "validation_failed", validationErrs.Error()),
+		}
+		for _, fe := range validationErrs {
+			p.Errors = append(p.Errors, FieldError{
+				Field:   fe.Field(),
+				Code:    fe.Tag(),
+				Message: i18n.FieldMessage(fe, lang),
+			})
+		}
+		return p
+	}
+
+	if errors.Is(err, ErrMalformedRequest) {
+		p := Problem{
+			Status: fiber.StatusBadRequest,
+			Code:   "malformed_request",
+			Detail: message(lang, "malformed_request", err.Error()),
+		}
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			p.Errors = append(p.Errors, FieldError{
+				Field:   typeErr.Field,
+				Code:    "invalid_type",
+				Message: message(lang, "invalid_type", typeErr.Error()),
+			})
+		}
+		return p
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		code := strings.ToLower(strings.ReplaceAll(utils.StatusMessage(fiberErr.Code), " ", "_"))
+		return Problem{
+			Status: fiberErr.Code,
+			Code:   code,
+			Detail: message(lang, code, fiberErr.Message),
+		}
+	}
+
+	return Problem{
+		Status: fiber.StatusInternalServerError,
+		Code:   "internal_error",
+		Detail: message(lang, "internal_error", "internal error"),
+	}
+}
+
+func statusOf(err *domain.Error) int {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, domain.ErrConflict):
+		return fiber.StatusConflict
+	case errors.Is(err, domain.ErrValidation):
+		return fiber.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrUnavailable):
+		return fiber.StatusServiceUnavailable
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
+func message(lang, code, fallback string) string {
+	if msg, ok := i18n.Message(lang, code); ok {
+		return msg
+	}
+	return fallback
+}
@@ -6,17 +6,32 @@ import (
 )
 
 type HealthCheckController struct {
+	checkers  map[string]healthcheck.Checker
+	readiness map[string]healthcheck.Checker
 }
 
-func NewHealthCheckController() *HealthCheckController {
-	return &HealthCheckController{}
+func NewHealthCheckController(checkers, readiness map[string]healthcheck.Checker) *HealthCheckController {
+	return &HealthCheckController{checkers: checkers, readiness: readiness}
 }
 
 func (h *HealthCheckController) HealthCheck(c *fiber.Ctx) error {
-	healthCheckhandler := healthcheck.NewHealthCheckHandler()
+	healthCheckhandler := healthcheck.NewHealthCheckHandler(h.checkers)
 	res, err := healthCheckhandler.Handle(c.UserContext(), &healthcheck.HealthCheckRequest{})
 	if err != nil {
-		return c.JSON(err.Error())
+		return err
 	}
-	return c.JSON(res.Message)
+	return c.JSON(res)
+}
+
+// Readiness, servis trafik almaya hazır değilse 503 döner.
+func (h *HealthCheckController) Readiness(c *fiber.Ctx) error {
+	readinessHandler := healthcheck.NewReadinessHandler(h.readiness)
+	res, err := readinessHandler.Handle(c.UserContext(), &healthcheck.ReadinessRequest{})
+	if err != nil {
+		return err
+	}
+	if !res.Ready {
+		c.Status(fiber.StatusServiceUnavailable)
+	}
+	return c.JSON(res)
 }
@@ -0,0 +1,29 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/EmreZURNACI/apistack/i18n"
+	"github.com/go-playground/validator/v10"
+)
+
+// Validate, controller'ların paylaştığı validator'dır.
+var Validate = New()
+
+// New, hata alanlarını struct adı yerine json etiketiyle raporlayan ve
+// i18n çevirileri kayıtlı bir validator döner.
+func New() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	if err := i18n.RegisterValidator(v); err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,74 @@
+package validation_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/EmreZURNACI/apistack/controller/validation"
+	"github.com/EmreZURNACI/apistack/i18n"
+	"github.com/go-playground/validator/v10"
+)
+
+type input struct {
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit" validate:"required_with=Cursor"`
+	Name   string `json:"name" validate:"required"`
+	Code   string `json:"code" validate:"omitempty,lowercase"`
+}
+
+// TestNewTwice, birden fazla validator oluşturulabildiğini ve her birinin
+// hatalarının çevrilebildiğini doğrular.
+func TestNewTwice(t *testing.T) {
+	for i := range 2 {
+		v := validation.New()
+		errs := validate(t, v, input{})
+		if got := i18n.FieldMessage(errs[0], i18n.English); got != "name is a required field" {
+			t.Fatalf("validator %d: message = %q", i, got)
+		}
+	}
+}
+
+func TestFieldMessage(t *testing.T) {
+	v := validation.New()
+	errs := validate(t, v, input{Cursor: "abc", Name: "x", Code: "NOPE"})
+
+	tests := []struct {
+		field string
+		lang  string
+		want  string
+	}{
+		{"limit", i18n.English, "limit is a required field"},
+		{"limit", i18n.Turkish, "limit zorunlu bir alandır"},
+		{"code", i18n.English, "code must be a lowercase string"},
+		// lowercase'in Türkçe çevirisi yok; genel mesaj döner.
+		{"code", i18n.Turkish, "code alanı geçersiz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field+"/"+tt.lang, func(t *testing.T) {
+			for _, fe := range errs {
+				if fe.Field() != tt.field {
+					continue
+				}
+				got := i18n.FieldMessage(fe, tt.lang)
+				if got != tt.want {
+					t.Fatalf("FieldMessage = %q, want %q", got, tt.want)
+				}
+				if strings.Contains(got, "Error:Field validation") {
+					t.Fatalf("FieldMessage returned raw validator text %q", got)
+				}
+				return
+			}
+			t.Fatalf("no error for field %s", tt.field)
+		})
+	}
+}
+
+func validate(t *testing.T, v *validator.Validate, i input) validator.ValidationErrors {
+	t.Helper()
+	var errs validator.ValidationErrors
+	if err := v.Struct(&i); !errors.As(err, &errs) {
+		t.Fatalf("Struct = %v, want validation errors", err)
+	}
+	return errs
+}
@@ -1,10 +1,58 @@
 package domain
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
 
 type Actor struct {
 	ID         int64     `json:"ID" gorm:"primaryKey;type:SERIAL;"`
-	FirstName  string    `json:"FirstName" gorm:"type:VARCHAR(100);NOT NULL;"`
-	LastName   string    `json:"LastName" gorm:"type:VARCHAR(100);NOT NULL;"`
+	FirstName  string    `json:"FirstName" gorm:"type:VARCHAR(100);NOT NULL;uniqueIndex:idx_actors_full_name;"`
+	LastName   string    `json:"LastName" gorm:"type:VARCHAR(100);NOT NULL;uniqueIndex:idx_actors_full_name;"`
 	LastUpdate time.Time `json:"LastUpdate" gorm:"default:CURRENT_TIMESTAMP;NOT NULL;"`
+	// Score, bulanık aramada kaydın aramaya benzerliğidir (0-1). Tabloda
+	// kolonu yoktur; yalnızca sorgu sonucundan okunur.
+	Score *float64 `json:"Score,omitempty" gorm:"->;-:migration"`
+}
+
+// ActorPatch, kısmi güncellemede değiştirilecek alanlardır; nil alanlar
+// olduğu gibi kalır.
+type ActorPatch struct {
+	FirstName *string
+	LastName  *string
+	// If verilmişse güncelleme yalnızca kayıt bu değerleri hâlâ taşıyorsa
+	// yapılır; taşımıyorsa ErrPatchTestFailed döner.
+	If ActorCondition
+}
+
+// ActorCondition, koşullu güncellemede kaydın sahip olması gereken
+// değerlerdir; nil alanlar kontrol edilmez.
+type ActorCondition struct {
+	FirstName  *string
+	LastName   *string
+	LastUpdate *time.Time
+}
+
+func (c ActorCondition) Empty() bool {
+	return c.FirstName == nil && c.LastName == nil && c.LastUpdate == nil
+}
+
+// Match, kaydın koşulu sağlayıp sağlamadığını döner.
+func (c ActorCondition) Match(actor Actor) bool {
+	return (c.FirstName == nil || *c.FirstName == actor.FirstName) &&
+		(c.LastName == nil || *c.LastName == actor.LastName) &&
+		(c.LastUpdate == nil || c.LastUpdate.Equal(actor.LastUpdate))
+}
+
+func (p ActorPatch) Empty() bool {
+	return p.FirstName == nil && p.LastName == nil
+}
+
+// ParseActorID, dışarıdan gelen id değerini doğrular.
+func ParseActorID(id string) (int64, error) {
+	actorID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || actorID <= 0 {
+		return 0, ErrInvalidActorID
+	}
+	return actorID, nil
 }
@@ -0,0 +1,49 @@
+package domain
+
+import "errors"
+
+// Hata türleri. HTTP katmanı durum kodunu bu türlere göre belirler.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrConflict    = errors.New("conflict")
+	ErrValidation  = errors.New("validation failed")
+	ErrUnavailable = errors.New("service unavailable")
+)
+
+// Error, istemciye dönülebilecek kodlu bir hatadır.
+// Code sabittir ve istemciler tarafından karşılaştırmada kullanılabilir.
+type Error struct {
+	Code    string
+	Message string
+	kind    error
+}
+
+func NewError(kind error, code, message string) *Error {
+	return &Error{
+		Code:    code,
+		Message: message,
+		kind:    kind,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.kind
+}
+
+var (
+	ErrActorNotFound       = NewError(ErrNotFound, "actor_not_found", "bu id'li kullanıcı bulunmamaktadır")
+	ErrActorAlreadyExists  = NewError(ErrConflict, "actor_already_exists", "bu bilgilere ait kullanıcı zaten mevcut")
+	ErrActorUnchanged      = NewError(ErrConflict, "actor_unchanged", "aktör bilgileri mevcut bilgilerle aynı")
+	ErrInvalidActorID      = NewError(ErrValidation, "invalid_actor_id", "geçersiz aktör id'si")
+	ErrInvalidCursor       = NewError(ErrValidation, "invalid_cursor", "geçersiz sayfalama imleci")
+	ErrInvalidSort         = NewError(ErrValidation, "invalid_sort", "geçersiz sıralama alanı")
+	ErrInvalidPatch        = NewError(ErrValidation, "invalid_patch", "patch dokümanı uygulanamadı")
+	ErrPatchTestFailed     = NewError(ErrConflict, "patch_test_failed", "patch test işlemi mevcut değerle eşleşmedi")
+	ErrDatabaseUnavailable = NewError(ErrUnavailable, "database_unavailable", "veritabanına şu anda erişilemiyor")
+	ErrCacheUnavailable    = NewError(ErrUnavailable, "cache_unavailable", "cache'e şu anda erişilemiyor")
+	ErrCacheKeyNotFound    = NewError(ErrNotFound, "cache_key_not_found", "cache anahtarı bulunamadı")
+)
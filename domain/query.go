@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// ActorQuery, aktör listesinin filtre, sıralama ve sayfalama
+// parametreleridir.
+type ActorQuery struct {
+	Search string
+	// Fuzzy true ise Search, ad soyad üzerinde aksan ve büyük/küçük harf
+	// duyarsız benzerlik araması yapar ve kayıtların Score alanı dolar.
+	// Sort boşsa sonuçlar benzerliğe göre azalan sıralanır.
+	Fuzzy  bool
+	Filter ActorFilter
+	// Sort boşsa id'ye göre artan sıralanır. Sıralamanın tekil olması için
+	// implementasyonlar SortFields ile sona id ekler.
+	Sort   []SortField
+	Offset int
+	Limit  int
+	// After veya Before verilirse keyset sayfalama yapılır: yalnızca
+	// imleçteki kayıttan sonra/önce gelen kayıtlar, sıralama korunarak
+	// döner. Before ile dönen kayıtlar imlece en yakın Limit kayıttır.
+	// Offset ile birlikte kullanılmaz.
+	After  *Cursor
+	Before *Cursor
+}
+
+// ActorFilter'ın boş alanları filtre uygulanmadığı anlamına gelir. Ad
+// filtreleri büyük/küçük harfe duyarlıdır. UpdatedSince dahil,
+// UpdatedBefore hariçtir.
+type ActorFilter struct {
+	FirstName       string
+	FirstNamePrefix string
+	LastName        string
+	LastNamePrefix  string
+	UpdatedSince    time.Time
+	UpdatedBefore   time.Time
+}
+
+// Sıralamaya izin verilen alanlar; değerler hem API parametresi hem de
+// kolon adıdır.
+const (
+	SortID         = "id"
+	SortFirstName  = "first_name"
+	SortLastName   = "last_name"
+	SortLastUpdate = "last_update"
+	// SortScore yalnızca bulanık aramada kullanılabilir.
+	SortScore = "score"
+)
+
+var sortable = map[string]bool{
+	SortID:         true,
+	SortFirstName:  true,
+	SortLastName:   true,
+	SortLastUpdate: true,
+	SortScore:      true,
+}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ParseActorSort, "last_name,-last_update" biçimindeki sıralamayı ayrıştırır.
+// "-" azalan sıralamadır. Yalnızca izin verilen alanlar kabul edilir.
+func ParseActorSort(value string) ([]SortField, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var fields []SortField
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		name := strings.TrimPrefix(strings.TrimPrefix(part, "-"), "+")
+		if !sortable[name] || seen[name] {
+			return nil, ErrInvalidSort
+		}
+		seen[name] = true
+		fields = append(fields, SortField{Field: name, Desc: desc})
+	}
+	return fields, nil
+}
+
+// SortFields, sıralamayı id ile tamamlanmış halde döner; böylece eşit
+// değerli kayıtların sırası her sorguda aynıdır. Bulanık aramada Sort boşsa
+// benzerliğe göre azalan sıralanır.
+func (q ActorQuery) SortFields() []SortField {
+	sort := q.Sort
+	if len(sort) == 0 && q.Fuzzy {
+		sort = []SortField{{Field: SortScore, Desc: true}}
+	}
+	fields := make([]SortField, 0, len(sort)+1)
+	for _, f := range sort {
+		fields = append(fields, f)
+		if f.Field == SortID {
+			return fields
+		}
+	}
+	return append(fields, SortField{Field: SortID})
+}
+
+// Cursor, keyset sayfalamada son görülen kaydın sıralanabilir alanlarıdır.
+type Cursor struct {
+	ID         int64
+	FirstName  string
+	LastName   string
+	LastUpdate time.Time
+	Score      float64
+}
+
+func CursorOf(actor Actor) Cursor {
+	c := Cursor{
+		ID:         actor.ID,
+		FirstName:  actor.FirstName,
+		LastName:   actor.LastName,
+		LastUpdate: actor.LastUpdate,
+	}
+	if actor.Score != nil {
+		c.Score = *actor.Score
+	}
+	return c
+}
+
+// Value, alanın imleçteki değerini döner.
+func (c Cursor) Value(field string) any {
+	switch field {
+	case SortFirstName:
+		return c.FirstName
+	case SortLastName:
+		return c.LastName
+	case SortLastUpdate:
+		return c.LastUpdate
+	case SortScore:
+		return c.Score
+	default:
+		return c.ID
+	}
+}
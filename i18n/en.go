@@ -0,0 +1,26 @@
+package i18n
+
+var enMessages = map[string]string{
+	"actor_not_found":        "no actor exists with this id",
+	"actor_already_exists":   "an actor with these details already exists",
+	"actor_unchanged":        "actor details are identical to the current ones",
+	"invalid_actor_id":       "invalid actor id",
+	"database_unavailable":   "the database is currently unavailable",
+	"validation_failed":      "request fields failed validation",
+	"malformed_request":      "the request could not be read",
+	"invalid_type":           "field has an invalid type",
+	"internal_error":         "an unexpected error occurred",
+	"not_found":              "the requested resource was not found",
+	"method_not_allowed":     "this method is not allowed",
+	"actor_deleted":          "Actor deleted",
+	"cache_unavailable":      "the cache is currently unavailable",
+	"cache_key_not_found":    "cache key not found",
+	"unauthorized":           "authentication is required",
+	"cache_flushed":          "Cache keys deleted",
+	"cache_key_deleted":      "Cache key deleted",
+	"invalid_cursor":         "invalid pagination cursor",
+	"invalid_sort":           "invalid sort field; allowed: id, first_name, last_name, last_update, score (fuzzy search only)",
+	"invalid_patch":          "the patch document could not be applied",
+	"patch_test_failed":      "a patch test operation did not match the current value",
+	"unsupported_media_type": "unsupported content type",
+}
@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"context"
+
+	"github.com/spf13/viper"
+	"golang.org/x/text/language"
+)
+
+const (
+	Turkish = "tr"
+	English = "en"
+)
+
+var bundles = map[string]map[string]string{
+	Turkish: trMessages,
+	English: enMessages,
+}
+
+type ctxKey struct{}
+
+// DefaultLanguage, config.yaml'daki i18n.default_language değerini döner.
+// Tanımsız veya desteklenmeyen bir değer için Türkçe kullanılır.
+func DefaultLanguage() string {
+	lang := viper.GetString("i18n.default_language")
+	if _, ok := bundles[lang]; !ok {
+		return Turkish
+	}
+	return lang
+}
+
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, lang)
+}
+
+func FromContext(ctx context.Context) string {
+	if lang, ok := ctx.Value(ctxKey{}).(string); ok {
+		return lang
+	}
+	return DefaultLanguage()
+}
+
+// Match, Accept-Language başlığına göre desteklenen en uygun dili seçer.
+func Match(acceptLanguage, fallback string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return fallback
+	}
+
+	supported := []language.Tag{language.Make(fallback)}
+	for lang := range bundles {
+		if lang != fallback {
+			supported = append(supported, language.Make(lang))
+		}
+	}
+
+	_, index, confidence := language.NewMatcher(supported).Match(tags...)
+	if confidence == language.No {
+		return fallback
+	}
+	base, _ := supported[index].Base()
+	return base.String()
+}
+
+// Message, verilen dildeki mesajı döner. Mesaj o dilde yoksa varsayılan
+// dile bakılır.
+func Message(lang, key string) (string, bool) {
+	if msg, ok := bundles[lang][key]; ok {
+		return msg, true
+	}
+	msg, ok := bundles[DefaultLanguage()][key]
+	return msg, ok
+}
+
+// T, context'teki dile göre mesajı döner; bulunamazsa anahtarın kendisini döner.
+func T(ctx context.Context, key string) string {
+	if msg, ok := Message(FromContext(ctx), key); ok {
+		return msg
+	}
+	return key
+}
@@ -0,0 +1,19 @@
+package i18n
+
+import "github.com/gofiber/fiber/v2"
+
+// New, her istek için Accept-Language başlığından dili seçip
+// UserContext'e ekleyen middleware'i döner.
+func New() fiber.Handler {
+	fallback := DefaultLanguage()
+
+	return func(c *fiber.Ctx) error {
+		lang := Match(c.Get(fiber.HeaderAcceptLanguage), fallback)
+
+		c.SetUserContext(WithLanguage(c.UserContext(), lang))
+		c.Set(fiber.HeaderContentLanguage, lang)
+		c.Vary(fiber.HeaderAcceptLanguage)
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,26 @@
+package i18n
+
+var trMessages = map[string]string{
+	"actor_not_found":        "bu id'li kullanıcı bulunmamaktadır",
+	"actor_already_exists":   "bu bilgilere ait kullanıcı zaten mevcut",
+	"actor_unchanged":        "aktör bilgileri mevcut bilgilerle aynı",
+	"invalid_actor_id":       "geçersiz aktör id'si",
+	"database_unavailable":   "veritabanına şu anda erişilemiyor",
+	"validation_failed":      "istek alanları doğrulanamadı",
+	"malformed_request":      "istek okunamadı",
+	"invalid_type":           "alan tipi geçersiz",
+	"internal_error":         "beklenmeyen bir hata oluştu",
+	"not_found":              "istenen kaynak bulunamadı",
+	"method_not_allowed":     "bu metoda izin verilmiyor",
+	"actor_deleted":          "Aktör silindi",
+	"cache_unavailable":      "cache'e şu anda erişilemiyor",
+	"cache_key_not_found":    "cache anahtarı bulunamadı",
+	"unauthorized":           "kimlik doğrulaması gerekli",
+	"cache_flushed":          "Cache anahtarları silindi",
+	"cache_key_deleted":      "Cache anahtarı silindi",
+	"invalid_cursor":         "geçersiz sayfalama imleci",
+	"invalid_sort":           "geçersiz sıralama alanı; izin verilenler: id, first_name, last_name, last_update, score (yalnızca bulanık aramada)",
+	"invalid_patch":          "patch dokümanı uygulanamadı",
+	"patch_test_failed":      "patch test işlemi mevcut değerle eşleşmedi",
+	"unsupported_media_type": "desteklenmeyen içerik tipi",
+}
@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"errors"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/tr"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	tr_translations "github.com/go-playground/validator/v10/translations/tr"
+)
+
+var universal = ut.New(en.New(), en.New(), tr.New())
+
+// translators, dil başına tek çevirmeni tutar. Validator çeviri
+// fonksiyonlarını çevirmene göre sakladığı için kayıt ve çeviri aynı
+// değerle yapılmalıdır.
+var translators = map[string]ut.Translator{
+	English: shared(English),
+	Turkish: shared(Turkish),
+}
+
+// fallbackKey, dilde etiketin çevirisi bulunmadığında kullanılan mesajdır.
+const fallbackKey = "invalid_field"
+
+var fallbacks = map[string]string{
+	English: "{0} is invalid",
+	Turkish: "{0} alanı geçersiz",
+}
+
+// trExtras, validator'ın Türkçe varsayılanlarında bulunmayan etiketlerdir.
+var trExtras = map[string]string{
+	"required_with": "{0} zorunlu bir alandır",
+	"excluded_with": "{0} bu alanlarla birlikte gönderilemez",
+	"datetime":      "{0} alanı {1} formatıyla eşleşmiyor",
+}
+
+// RegisterValidator, validator hata mesajlarının desteklenen tüm dillere
+// çevrilebilmesi için çevirileri kaydeder. Aynı süreçte birden fazla
+// validator için çağrılabilir; metinler ortak çevirmenlere bir kez eklenir.
+func RegisterValidator(v *validator.Validate) error {
+	registrations := map[string]func(*validator.Validate, ut.Translator) error{
+		English: en_translations.RegisterDefaultTranslations,
+		Turkish: tr_translations.RegisterDefaultTranslations,
+	}
+
+	for lang, register := range registrations {
+		trans := Translator(lang)
+		if err := register(v, trans); err != nil {
+			return err
+		}
+		if err := trans.Add(fallbackKey, fallbacks[lang], false); err != nil {
+			return err
+		}
+	}
+
+	trans := Translator(Turkish)
+	for tag, text := range trExtras {
+		err := v.RegisterTranslation(tag, trans, func(trans ut.Translator) error {
+			return trans.Add(tag, text, false)
+		}, func(trans ut.Translator, fe validator.FieldError) string {
+			msg, _ := trans.T(fe.Tag(), fe.Field(), fe.Param())
+			return msg
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Translator, dilin çevirmenini döner; desteklenmeyen dillerde İngilizce
+// kullanılır.
+func Translator(lang string) ut.Translator {
+	if trans, ok := translators[lang]; ok {
+		return trans
+	}
+	return translators[English]
+}
+
+// FieldMessage, alan hatasını verilen dile çevirir. Dilde etiketin çevirisi
+// yoksa ham validator mesajı yerine genel "alan geçersiz" mesajı döner.
+func FieldMessage(fe validator.FieldError, lang string) string {
+	trans := Translator(lang)
+	if msg := fe.Translate(trans); msg != "" && msg != fe.Error() {
+		return msg
+	}
+	msg, err := trans.T(fallbackKey, fe.Field())
+	if err != nil {
+		return fe.Error()
+	}
+	return msg
+}
+
+// sharedTranslator, aynı metnin ikinci kez eklenmesini hata saymaz; metinler
+// tüm validator'lar için aynıdır.
+type sharedTranslator struct {
+	ut.Translator
+}
+
+func shared(lang string) ut.Translator {
+	trans, _ := universal.GetTranslator(lang)
+	return sharedTranslator{trans}
+}
+
+func (t sharedTranslator) Add(key any, text string, override bool) error {
+	return ignoreConflict(t.Translator.Add(key, text, override))
+}
+
+func (t sharedTranslator) AddCardinal(key any, text string, rule locales.PluralRule, override bool) error {
+	return ignoreConflict(t.Translator.AddCardinal(key, text, rule, override))
+}
+
+func (t sharedTranslator) AddOrdinal(key any, text string, rule locales.PluralRule, override bool) error {
+	return ignoreConflict(t.Translator.AddOrdinal(key, text, rule, override))
+}
+
+func (t sharedTranslator) AddRange(key any, text string, rule locales.PluralRule, override bool) error {
+	return ignoreConflict(t.Translator.AddRange(key, text, rule, override))
+}
+
+func ignoreConflict(err error) error {
+	var conflict *ut.ErrConflictingTranslation
+	if errors.As(err, &conflict) {
+		return nil
+	}
+	return err
+}
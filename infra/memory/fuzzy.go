@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// wordSimilarityThreshold, pg_trgm.word_similarity_threshold'un varsayılan
+// değeridir; Postgres'teki <% operatörü bu eşiği kullanır.
+const wordSimilarityThreshold = 0.6
+
+// unaccentLetters, ayrıştırıldığında işaretine ayrılmayan harflerin
+// unaccent sözlüğündeki karşılıklarıdır.
+var unaccentLetters = strings.NewReplacer(
+	"ı", "i", "ø", "o", "Ø", "O", "ł", "l", "Ł", "L", "đ", "d", "Đ", "D",
+	"ß", "ss", "æ", "ae", "Æ", "AE", "œ", "oe", "Œ", "OE",
+)
+
+// searchText, Postgres'teki actor_search_text fonksiyonunun karşılığıdır:
+// aksanları atılmış ve küçük harfe çevrilmiş metin. İ, ş, ğ gibi harfler
+// NFD ile harf ve işarete ayrılıp işaret atılarak I, s, g olur.
+func searchText(value string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, unaccentLetters.Replace(value))
+	if err != nil {
+		folded = value
+	}
+	return strings.ToLower(folded)
+}
+
+// trigrams, pg_trgm'in yaptığı gibi metni harf ve rakam dışındaki
+// karakterlerden kelimelere böler; her kelimeyi başına iki, sonuna bir
+// boşluk ekleyerek üçlülere ayırır. Üçlüler metindeki sırasıyla döner.
+func trigrams(value string) []string {
+	var out []string
+	words := strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			out = append(out, string(padded[i:i+3]))
+		}
+	}
+	return out
+}
+
+// wordSimilarity, pg_trgm'deki word_similarity(query, text) karşılığıdır:
+// sorgunun üçlü kümesi ile metnin üçlülerinden oluşan herhangi bir ardışık
+// aralık arasındaki en yüksek benzerlik (ortak / birleşim).
+func wordSimilarity(query, text string) float64 {
+	q := make(map[string]bool)
+	for _, t := range trigrams(query) {
+		q[t] = true
+	}
+	if len(q) == 0 {
+		return 0
+	}
+
+	ordered := trigrams(text)
+	best := 0.0
+	for i := range ordered {
+		extent := make(map[string]bool)
+		common := 0
+		for _, t := range ordered[i:] {
+			if !extent[t] {
+				extent[t] = true
+				if q[t] {
+					common++
+				}
+			}
+			if s := float64(common) / float64(len(q)+len(extent)-common); s > best {
+				best = s
+			}
+		}
+	}
+	return best
+}
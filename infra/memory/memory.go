@@ -0,0 +1,343 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EmreZURNACI/apistack/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// MemoryHandler, app/actor.Repository'nin süreç içi implementasyonudur.
+// Testlerde ve lokal geliştirmede Postgres yerine kullanılır; davranışı
+// PostgresHandler ile aynı olacak şekilde yazılmıştır.
+type MemoryHandler struct {
+	mu     sync.RWMutex
+	actors map[int64]domain.Actor
+	lastID int64
+	tracer trace.Tracer
+}
+
+func GetMemoryHandler(tracer trace.Tracer) *MemoryHandler {
+	return &MemoryHandler{
+		actors: make(map[int64]domain.Actor),
+		tracer: tracer,
+	}
+}
+
+func (h *MemoryHandler) GetActors(ctx context.Context, query domain.ActorQuery) ([]domain.Actor, error) {
+	_, span := h.tracer.Start(ctx, "GetActors")
+	defer span.End()
+
+	// Before ile imlece en yakın kayıtlar ters sırada seçilip sonra
+	// çevrilir.
+	fields := query.SortFields()
+	if query.Before != nil {
+		for i := range fields {
+			fields[i].Desc = !fields[i].Desc
+		}
+	}
+
+	match := filterActors(query)
+	cursor := cmp.Or(query.After, query.Before)
+
+	h.mu.RLock()
+	actors := make([]domain.Actor, 0, len(h.actors))
+	for _, actor := range h.actors {
+		actor, ok := match(actor)
+		if !ok {
+			continue
+		}
+		if cursor != nil && compareActors(domain.CursorOf(actor), *cursor, fields) <= 0 {
+			continue
+		}
+		actors = append(actors, actor)
+	}
+	h.mu.RUnlock()
+
+	slices.SortFunc(actors, func(a, b domain.Actor) int {
+		return compareActors(domain.CursorOf(a), domain.CursorOf(b), fields)
+	})
+
+	if query.Offset > 0 {
+		actors = actors[min(query.Offset, len(actors)):]
+	}
+
+	if query.Limit > 0 {
+		actors = actors[:min(query.Limit, len(actors))]
+	}
+
+	if query.Before != nil {
+		slices.Reverse(actors)
+	}
+
+	return actors, nil
+}
+
+// compareActors, a'nın sıralamada b'den önce (<0) veya sonra (>0) gelip
+// gelmediğini döner.
+func compareActors(a, b domain.Cursor, fields []domain.SortField) int {
+	for _, f := range fields {
+		var c int
+		switch f.Field {
+		case domain.SortFirstName:
+			c = strings.Compare(a.FirstName, b.FirstName)
+		case domain.SortLastName:
+			c = strings.Compare(a.LastName, b.LastName)
+		case domain.SortLastUpdate:
+			c = a.LastUpdate.Compare(b.LastUpdate)
+		case domain.SortScore:
+			c = cmp.Compare(a.Score, b.Score)
+		default:
+			c = cmp.Compare(a.ID, b.ID)
+		}
+		if f.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// CountActors, filtreye uyan kayıt sayısını döner. Sayı her zaman kesindir.
+func (h *MemoryHandler) CountActors(ctx context.Context, query domain.ActorQuery, estimate bool) (int64, bool, error) {
+	_, span := h.tracer.Start(ctx, "CountActors")
+	defer span.End()
+
+	match := filterActors(query)
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var total int64
+	for _, actor := range h.actors {
+		if _, ok := match(actor); ok {
+			total++
+		}
+	}
+	return total, false, nil
+}
+
+// filterActors, PostgresHandler'daki WHERE koşullarının karşılığıdır.
+// Bulanık aramada eşleşen kaydın Score alanı doldurulur.
+func filterActors(query domain.ActorQuery) func(domain.Actor) (domain.Actor, bool) {
+	var pattern *regexp.Regexp
+	if query.Search != "" && !query.Fuzzy {
+		pattern = ilike("%" + query.Search + "%")
+	}
+	search := searchText(query.Search)
+
+	f := query.Filter
+	match := func(actor domain.Actor) bool {
+		switch {
+		case pattern != nil && !pattern.MatchString(actor.FirstName) && !pattern.MatchString(actor.LastName):
+			return false
+		case f.FirstName != "" && actor.FirstName != f.FirstName:
+			return false
+		case f.FirstNamePrefix != "" && !strings.HasPrefix(actor.FirstName, f.FirstNamePrefix):
+			return false
+		case f.LastName != "" && actor.LastName != f.LastName:
+			return false
+		case f.LastNamePrefix != "" && !strings.HasPrefix(actor.LastName, f.LastNamePrefix):
+			return false
+		case !f.UpdatedSince.IsZero() && actor.LastUpdate.Before(f.UpdatedSince):
+			return false
+		case !f.UpdatedBefore.IsZero() && !actor.LastUpdate.Before(f.UpdatedBefore):
+			return false
+		default:
+			return true
+		}
+	}
+	return func(actor domain.Actor) (domain.Actor, bool) {
+		if !match(actor) {
+			return actor, false
+		}
+		if query.Fuzzy {
+			score := wordSimilarity(search, searchText(actor.FirstName+" "+actor.LastName))
+			if score < wordSimilarityThreshold {
+				return actor, false
+			}
+			actor.Score = &score
+		}
+		return actor, true
+	}
+}
+
+func (h *MemoryHandler) CreateActor(ctx context.Context, firstName, lastName string) (int64, error) {
+	_, span := h.tracer.Start(ctx, "CreateActor")
+	defer span.End()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, actor := range h.actors {
+		if actor.FirstName == firstName && actor.LastName == lastName {
+			return -1, domain.ErrActorAlreadyExists
+		}
+	}
+
+	h.lastID++
+	h.actors[h.lastID] = domain.Actor{
+		ID:         h.lastID,
+		FirstName:  firstName,
+		LastName:   lastName,
+		LastUpdate: time.Now().Truncate(time.Microsecond),
+	}
+
+	return h.lastID, nil
+}
+
+func (h *MemoryHandler) DeleteActor(ctx context.Context, id string) error {
+	_, span := h.tracer.Start(ctx, "DeleteActor")
+	defer span.End()
+
+	actorID, err := domain.ParseActorID(id)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.actors[actorID]; !ok {
+		return domain.ErrActorNotFound
+	}
+	delete(h.actors, actorID)
+
+	return nil
+}
+
+func (h *MemoryHandler) GetActor(ctx context.Context, id string) (*domain.Actor, error) {
+	_, span := h.tracer.Start(ctx, "GetActor")
+	defer span.End()
+
+	actorID, err := domain.ParseActorID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.RLock()
+	actor, ok := h.actors[actorID]
+	h.mu.RUnlock()
+
+	if !ok {
+		zap.L().Info("Bu id'li kullanıcı bulunmamaktadır", zap.String("id", id))
+		return nil, domain.ErrActorNotFound
+	}
+
+	return &actor, nil
+}
+
+func (h *MemoryHandler) UpdateActor(ctx context.Context, id, firstname, lastname string) error {
+	_, span := h.tracer.Start(ctx, "UpdateActor")
+	defer span.End()
+
+	actorID, err := domain.ParseActorID(id)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	actor, ok := h.actors[actorID]
+	if !ok {
+		return domain.ErrActorNotFound
+	}
+
+	if actor.FirstName == firstname && actor.LastName == lastname {
+		return domain.ErrActorUnchanged
+	}
+
+	for _, other := range h.actors {
+		if other.FirstName == firstname && other.LastName == lastname {
+			return domain.ErrActorAlreadyExists
+		}
+	}
+
+	actor.FirstName = firstname
+	actor.LastName = lastname
+	actor.LastUpdate = time.Now().Truncate(time.Microsecond)
+	h.actors[actorID] = actor
+
+	zap.L().Info("actor güncellendi", zap.String("id", id))
+	return nil
+}
+
+func (h *MemoryHandler) PatchActor(ctx context.Context, id string, patch domain.ActorPatch) (*domain.Actor, error) {
+	_, span := h.tracer.Start(ctx, "PatchActor")
+	defer span.End()
+
+	actorID, err := domain.ParseActorID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	actor, ok := h.actors[actorID]
+	if !ok {
+		return nil, domain.ErrActorNotFound
+	}
+	if !patch.If.Match(actor) {
+		return nil, domain.ErrPatchTestFailed
+	}
+	if patch.Empty() {
+		return &actor, nil
+	}
+
+	if patch.FirstName != nil {
+		actor.FirstName = *patch.FirstName
+	}
+	if patch.LastName != nil {
+		actor.LastName = *patch.LastName
+	}
+
+	for _, other := range h.actors {
+		if other.ID != actorID && other.FirstName == actor.FirstName && other.LastName == actor.LastName {
+			return nil, domain.ErrActorAlreadyExists
+		}
+	}
+
+	actor.LastUpdate = time.Now().Truncate(time.Microsecond)
+	h.actors[actorID] = actor
+
+	zap.L().Info("actor güncellendi", zap.String("id", id))
+	return &actor, nil
+}
+
+// ilike, Postgres ILIKE desenini (%, _ ve \ kaçışı) büyük/küçük harf
+// duyarsız bir regexp'e çevirir.
+func ilike(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("(?is)^")
+
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			b.WriteString(".*")
+		case r == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,16 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/EmreZURNACI/apistack/app/actor"
+	"github.com/EmreZURNACI/apistack/app/actor/repositorytest"
+	"github.com/EmreZURNACI/apistack/infra/memory"
+	"go.opentelemetry.io/otel"
+)
+
+func TestMemoryHandler(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) actor.Repository {
+		return memory.GetMemoryHandler(otel.Tracer("test"))
+	})
+}
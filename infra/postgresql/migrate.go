@@ -0,0 +1,287 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID, aynı anda başlayan replikaların migration'ları birlikte
+// çalıştırmasını engelleyen pg_advisory_lock anahtarıdır.
+const migrationLockID = 7_203_114_001
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Migrator, migrations klasöründeki sıralı SQL dosyalarını uygular ve
+// uygulananları schema_migrations tablosunda tutar.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+func NewMigrator() (*Migrator, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := open()
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         sqlDB,
+		migrations: migrations,
+	}, nil
+}
+
+func (m *Migrator) Close() error {
+	return m.db.Close()
+}
+
+// Up, henüz uygulanmamış tüm migration'ları sırayla uygular.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			err := inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx,
+					`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`,
+					migration.Version, migration.Name)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			zap.L().Info("migration uygulandı", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down, en son uygulanan migration'ı geri alır. Geri alınacak migration
+// yoksa nil döner.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var reverted *Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+
+			err := inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			zap.L().Info("migration geri alındı", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+			reverted = &migration
+			return nil
+		}
+		return nil
+	})
+
+	return reverted, err
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Migration: migration}
+			if appliedAt, ok := done[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+
+	return statuses, err
+}
+
+// withLock, advisory lock'u tek bir bağlantı üzerinde alır; lock oturuma
+// bağlı olduğu için tüm işlemler aynı bağlantıdan yapılır.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("advisory lock alınamadı: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID); err != nil {
+			zap.L().Error("advisory lock bırakılamadı", zap.Error(err))
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    BIGINT PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("schema_migrations oluşturulamadı: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		done[version] = appliedAt
+	}
+	return done, rows.Err()
+}
+
+func inTx(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// loadMigrations, <version>_<name>.up.sql ve <version>_<name>.down.sql
+// dosyalarını version'a göre sıralı olarak okur.
+func loadMigrations() ([]Migration, error) {
+	files, err := fs.Glob(migrationFiles, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, file := range files {
+		base := path.Base(file)
+
+		var direction string
+		switch {
+		case strings.HasSuffix(base, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(base, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("geçersiz migration dosyası: %s", base)
+		}
+
+		prefix, name, ok := strings.Cut(strings.TrimSuffix(base, "."+direction+".sql"), "_")
+		if !ok {
+			return nil, fmt.Errorf("geçersiz migration dosyası: %s", base)
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("geçersiz migration version'ı: %s", base)
+		}
+
+		content, err := migrationFiles.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s için up ve down dosyaları gerekli", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
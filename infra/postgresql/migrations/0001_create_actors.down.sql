@@ -0,0 +1,8 @@
+-- 0001 başlangıç (baseline) migration'ıdır: actors tablosu dvdrental ile
+-- birlikte gelir ve bu migration onu yalnızca yoksa oluşturur. Geri almak
+-- migration'ın oluşturmadığı veriyi sileceği için reddedilir.
+DO $$
+BEGIN
+    RAISE EXCEPTION 'migration 0001 is the baseline and cannot be reverted; drop the actors table by hand if that is really intended';
+END
+$$;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS actors (
+    id          SERIAL PRIMARY KEY,
+    first_name  VARCHAR(100) NOT NULL,
+    last_name   VARCHAR(100) NOT NULL,
+    last_update TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_actors_full_name;
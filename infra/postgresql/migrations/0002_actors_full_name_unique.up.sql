@@ -0,0 +1,5 @@
+CREATE UNIQUE INDEX IF NOT EXISTS idx_actors_full_name ON actors (first_name, last_name);
+
+-- Eski sürümler id'yi "max id + 1" ile elle verdiği için sequence geride
+-- kalmış olabilir; sequence'i mevcut en büyük id'nin ilerisine taşı.
+SELECT setval(pg_get_serial_sequence('actors', 'id'), COALESCE((SELECT MAX(id) FROM actors), 0) + 1, false);
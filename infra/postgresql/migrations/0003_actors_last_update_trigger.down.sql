@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS actors_last_update ON actors;
+DROP FUNCTION IF EXISTS actors_set_last_update();
@@ -0,0 +1,12 @@
+CREATE OR REPLACE FUNCTION actors_set_last_update() RETURNS trigger AS $$
+BEGIN
+    NEW.last_update = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS actors_last_update ON actors;
+CREATE TRIGGER actors_last_update
+    BEFORE UPDATE ON actors
+    FOR EACH ROW
+    EXECUTE FUNCTION actors_set_last_update();
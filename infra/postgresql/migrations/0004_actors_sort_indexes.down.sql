@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_actors_last_update;
+DROP INDEX IF EXISTS idx_actors_last_name;
@@ -0,0 +1,5 @@
+-- Liste uçundaki sıralama ve keyset sayfalama için. Eşit değerleri ayırmak
+-- için id her sıralamanın son alanıdır. first_name sıralaması
+-- idx_actors_full_name'i kullanır.
+CREATE INDEX IF NOT EXISTS idx_actors_last_name ON actors (last_name, id);
+CREATE INDEX IF NOT EXISTS idx_actors_last_update ON actors (last_update, id);
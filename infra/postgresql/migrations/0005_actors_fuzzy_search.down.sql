@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_actors_search_trgm;
+DROP FUNCTION IF EXISTS actor_search_text(text, text);
+DROP FUNCTION IF EXISTS f_unaccent(text);
+-- Eklentiler başka nesneler tarafından kullanılıyor olabileceği için
+-- kaldırılmaz.
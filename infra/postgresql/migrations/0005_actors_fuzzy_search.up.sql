@@ -0,0 +1,18 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() STABLE olduğu için index ifadesinde kullanılamaz; sözlüğü
+-- sabitleyen IMMUTABLE bir sarmalayıcı gerekir.
+CREATE OR REPLACE FUNCTION f_unaccent(text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$;
+
+-- Arama metni: aksanları atılmış, küçük harfli ad soyad. İ/ı, ş, ğ gibi
+-- Türkçe karakterler unaccent ile I/i, s, g'ye çevrilir; lower()'dan önce
+-- uygulandığı için İ locale'den bağımsız olarak i olur.
+CREATE OR REPLACE FUNCTION actor_search_text(first_name text, last_name text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+AS $$ SELECT lower(f_unaccent(first_name || ' ' || last_name)) $$;
+
+CREATE INDEX IF NOT EXISTS idx_actors_search_trgm
+    ON actors USING gin (actor_search_text(first_name, last_name) gin_trgm_ops);
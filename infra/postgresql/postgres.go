@@ -1,9 +1,12 @@
 package postgresql
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/EmreZURNACI/apistack/domain"
@@ -13,6 +16,7 @@ import (
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/plugin/opentelemetry/tracing"
 )
 
@@ -22,6 +26,25 @@ type PostgresHandler struct {
 }
 
 func GetPostgresHandler(tracer trace.Tracer) (*PostgresHandler, error) {
+	db, err := open()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Use(tracing.NewPlugin()); err != nil {
+		zap.L().Error("gorm tracing plugin eklenemedi")
+		return nil, err
+	}
+
+	return &PostgresHandler{
+		db:     db,
+		tracer: tracer,
+	}, nil
+}
+
+// open, config'teki bilgilerle Postgres'e bağlanır. Şema değişiklikleri
+// burada yapılmaz; bkz. Migrator.
+func open() (*gorm.DB, error) {
 	var dsn = fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		viper.GetString("database.hostname"),
 		viper.GetString("database.port"),
@@ -33,7 +56,7 @@ func GetPostgresHandler(tracer trace.Tracer) (*PostgresHandler, error) {
 	var err error
 
 	for i := range 5 {
-		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 		if err == nil {
 			sqlDB, pingErr := db.DB()
 			if pingErr == nil && sqlDB.Ping() == nil {
@@ -50,102 +73,201 @@ func GetPostgresHandler(tracer trace.Tracer) (*PostgresHandler, error) {
 		return nil, err
 	}
 
-	if err := db.Use(tracing.NewPlugin()); err != nil {
-		zap.L().Error("gorm tracing plugin eklenemedi")
+	// Havuz sınırlanmazsa yoğun trafikte her istek yeni bağlantı açar ve
+	// Postgres'in max_connections sınırına takılır; sınırda istekler boş
+	// bağlantı bekler.
+	sqlDB, err := db.DB()
+	if err != nil {
 		return nil, err
 	}
-
-	if err := db.AutoMigrate(&domain.Actor{}); err != nil {
-		zap.L().Error("table oluşturulamadı")
-		return nil, err
+	maxOpen := viper.GetInt("database.max_open_conns")
+	if maxOpen <= 0 {
+		maxOpen = 20
 	}
+	sqlDB.SetMaxOpenConns(maxOpen)
+	sqlDB.SetMaxIdleConns(maxOpen)
 
-	return &PostgresHandler{
-		db:     db,
-		tracer: tracer,
-	}, nil
+	return db, nil
+}
+
+func (h *PostgresHandler) Close() error {
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
 }
 
-func (h *PostgresHandler) GetActors(ctx context.Context, search string, offset, limit int, orderBy bool) ([]domain.Actor, error) {
+func (h *PostgresHandler) GetActors(ctx context.Context, query domain.ActorQuery) ([]domain.Actor, error) {
 	ctx, span := h.tracer.Start(ctx, "GetActors")
 	defer span.End()
 
-	db := h.db.WithContext(ctx).Model(&domain.Actor{})
+	db := filterActors(h.actors(ctx, query), query)
 
-	if search != "" {
-		db = db.Where("first_name ILIKE ? OR last_name ILIKE ?", "%"+search+"%", "%"+search+"%")
+	// Before ile imlece en yakın kayıtlar ters sırada okunup sonra
+	// çevrilir.
+	fields := query.SortFields()
+	if query.Before != nil {
+		for i := range fields {
+			fields[i].Desc = !fields[i].Desc
+		}
+	}
+
+	if cursor := cmp.Or(query.After, query.Before); cursor != nil {
+		where, args := keyset(fields, *cursor)
+		db = db.Where(where, args...)
 	}
 
-	if orderBy {
-		db = db.Order("id DESC")
+	for _, f := range fields {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: f.Field}, Desc: f.Desc})
 	}
 
-	if offset > 0 {
-		db = db.Offset(offset)
+	if query.Offset > 0 {
+		db = db.Offset(query.Offset)
 	}
 
-	if limit > 0 {
-		db = db.Limit(limit)
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
 	}
 
-	var actors []domain.Actor
+	actors := []domain.Actor{}
 	if err := db.Find(&actors).Error; err != nil {
 		zap.L().Error("failed to query actors", zap.Error(err))
-		return nil, errors.New("aktörler getirilirken bir sorun oluştu")
+		return nil, domain.ErrDatabaseUnavailable
 	}
 
-	if len(actors) == 0 {
-		zap.L().Info("kayıtlı aktör bulunamadı")
-		return nil, errors.New("kayıtlı aktör bulunamadı")
+	if query.Before != nil {
+		slices.Reverse(actors)
 	}
 
 	return actors, nil
 }
 
-func (h *PostgresHandler) CreateActor(ctx context.Context, firstName, lastName string) (int64, error) {
-	ctx, span := h.tracer.Start(ctx, "CreateActor")
-	defer span.End()
-
-	tx := h.db.WithContext(ctx).Model(&domain.Actor{}).Begin()
-	if tx.Error != nil {
-		zap.L().Error("failed to start transaction", zap.Error(tx.Error))
-		return 0, errors.New("transaction başlatılamadı")
+// actors, sorgunun okuyacağı kaynaktır. Bulanık aramada benzerlik puanı,
+// WHERE ve ORDER BY'da diğer kolonlar gibi kullanılabilmesi için alt
+// sorguda score kolonu olarak hesaplanır.
+func (h *PostgresHandler) actors(ctx context.Context, query domain.ActorQuery) *gorm.DB {
+	db := h.db.WithContext(ctx)
+	if !query.Fuzzy {
+		return db.Model(&domain.Actor{})
 	}
+	scored := h.db.Model(&domain.Actor{}).
+		Select("actors.*, word_similarity(lower(f_unaccent(?)), actor_search_text(first_name, last_name)) AS score", query.Search)
+	return db.Table("(?) AS actors", scored)
+}
 
-	defer func() {
-		//recover == panic durumlarını yakalar
-		if r := recover(); r != nil {
-			tx.Rollback()
+// keyset, sıralamada imleçten sonra gelen kayıtların koşulunu üretir:
+//
+//	(f1 > v1) OR (f1 = v1 AND f2 > v2) OR ...
+//
+// Alanlar farklı yönlerde sıralanabildiği için satır karşılaştırması
+// ((f1, f2) > (v1, v2)) kullanılamaz. Kolon adları domain'deki izin
+// listesinden gelir; değerler parametre olarak geçer.
+func keyset(fields []domain.SortField, cursor domain.Cursor) (string, []any) {
+	var (
+		ors  []string
+		args []any
+	)
+	for i, f := range fields {
+		var ands []string
+		for _, prev := range fields[:i] {
+			ands = append(ands, prev.Field+" = ?")
+			args = append(args, cursor.Value(prev.Field))
 		}
-	}()
+		op := " > ?"
+		if f.Desc {
+			op = " < ?"
+		}
+		ands = append(ands, f.Field+op)
+		args = append(args, cursor.Value(f.Field))
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+	return strings.Join(ors, " OR "), args
+}
 
-	var exist domain.Actor
-	err := tx.Where("first_name = ? AND last_name = ?", firstName, lastName).
-		First(&exist).Error
+// CountActors, filtreye uyan kayıt sayısını döner; imleç, offset ve limit
+// dikkate alınmaz. estimate true ise ve arama ya da filtre yoksa sayı COUNT
+// yerine pg_class istatistiklerinden okunur; bu durumda estimated true
+// döner.
+func (h *PostgresHandler) CountActors(ctx context.Context, query domain.ActorQuery, estimate bool) (int64, bool, error) {
+	ctx, span := h.tracer.Start(ctx, "CountActors")
+	defer span.End()
 
-	if err == nil {
-		tx.Rollback()
-		return -1, errors.New("bu bilgilere ait kullanıcı zaten mevcut")
+	if estimate && query.Search == "" && query.Filter == (domain.ActorFilter{}) {
+		var total int64
+		err := h.db.WithContext(ctx).
+			Raw("SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass(?)", "actors").
+			Scan(&total).Error
+		if err != nil {
+			zap.L().Error("failed to estimate actors", zap.Error(err))
+			return 0, false, domain.ErrDatabaseUnavailable
+		}
+		// Tablo hiç ANALYZE edilmemişse reltuples -1'dir; kesin sayıya
+		// düşülür.
+		if total >= 0 {
+			return total, true, nil
+		}
 	}
 
-	if !errors.Is(err, gorm.ErrRecordNotFound) {
-		tx.Rollback()
-		zap.L().Error("veritabanı sorgu hatası", zap.Error(err))
-		return -1, errors.New("sorgu hatası")
+	var total int64
+	if err := filterActors(h.db.WithContext(ctx).Model(&domain.Actor{}), query).Count(&total).Error; err != nil {
+		zap.L().Error("failed to count actors", zap.Error(err))
+		return 0, false, domain.ErrDatabaseUnavailable
 	}
+	return total, false, nil
+}
 
-	var count int64
-	tx.Select("id").Order("id DESC").Limit(1).Scan(&count)
-	actor := domain.Actor{ID: count + 1, FirstName: firstName, LastName: lastName}
-	if err := tx.Create(&actor).Error; err != nil {
-		tx.Rollback()
-		zap.L().Error("kayıt eklenirken hata oluştu", zap.Error(err))
-		return -1, errors.New("kayıt eklenirken hata oluştu")
+func filterActors(db *gorm.DB, query domain.ActorQuery) *gorm.DB {
+	switch {
+	case query.Fuzzy:
+		// <% operatörü idx_actors_search_trgm index'ini kullanır; eşik
+		// pg_trgm.word_similarity_threshold ayarıdır (varsayılan 0.6).
+		db = db.Where("lower(f_unaccent(?)) <% actor_search_text(first_name, last_name)", query.Search)
+	case query.Search != "":
+		db = db.Where("first_name ILIKE ? OR last_name ILIKE ?", "%"+query.Search+"%", "%"+query.Search+"%")
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		zap.L().Error("transaction commit hatası", zap.Error(err))
-		return -1, err
+	f := query.Filter
+	if f.FirstName != "" {
+		db = db.Where("first_name = ?", f.FirstName)
+	}
+	if f.FirstNamePrefix != "" {
+		db = db.Where(`first_name LIKE ? ESCAPE '\'`, escapeLike(f.FirstNamePrefix)+"%")
+	}
+	if f.LastName != "" {
+		db = db.Where("last_name = ?", f.LastName)
+	}
+	if f.LastNamePrefix != "" {
+		db = db.Where(`last_name LIKE ? ESCAPE '\'`, escapeLike(f.LastNamePrefix)+"%")
+	}
+	if !f.UpdatedSince.IsZero() {
+		db = db.Where("last_update >= ?", f.UpdatedSince)
+	}
+	if !f.UpdatedBefore.IsZero() {
+		db = db.Where("last_update < ?", f.UpdatedBefore)
+	}
+	return db
+}
+
+// escapeLike, önekteki LIKE joker karakterlerinin harfiyen eşleşmesini
+// sağlar.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func (h *PostgresHandler) CreateActor(ctx context.Context, firstName, lastName string) (int64, error) {
+	ctx, span := h.tracer.Start(ctx, "CreateActor")
+	defer span.End()
+
+	// id, SERIAL sütununun sequence'inden gelir; aynı isimli kayıtlar
+	// idx_actors_full_name unique index'i ile engellenir.
+	actor := domain.Actor{FirstName: firstName, LastName: lastName}
+	if err := h.db.WithContext(ctx).Create(&actor).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return -1, domain.ErrActorAlreadyExists
+		}
+		zap.L().Error("kayıt eklenirken hata oluştu", zap.Error(err))
+		return -1, domain.ErrDatabaseUnavailable
 	}
 
 	return actor.ID, nil
@@ -155,10 +277,15 @@ func (h *PostgresHandler) DeleteActor(ctx context.Context, id string) error {
 	ctx, span := h.tracer.Start(ctx, "DeleteActor")
 	defer span.End()
 
+	actorID, err := domain.ParseActorID(id)
+	if err != nil {
+		return err
+	}
+
 	tx := h.db.WithContext(ctx).Model(&domain.Actor{}).Begin()
 	if tx.Error != nil {
 		zap.L().Error("transaction başlatılamadı", zap.Error(tx.Error))
-		return errors.New("transaction başlatılamadı")
+		return domain.ErrDatabaseUnavailable
 	}
 
 	defer func() {
@@ -168,25 +295,25 @@ func (h *PostgresHandler) DeleteActor(ctx context.Context, id string) error {
 	}()
 
 	var actor domain.Actor
-	if err := tx.Where("id = ?", id).First(&actor).Error; err != nil {
+	if err := tx.Where("id = ?", actorID).First(&actor).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			tx.Rollback()
-			return errors.New("bu id'ye ait kullanıcı bulunmamaktadır")
+			return domain.ErrActorNotFound
 		}
 		tx.Rollback()
 		zap.L().Error("actor sorgusunda hata", zap.Error(err))
-		return errors.New("ilgili id'li actor bulunurken hata oluştu")
+		return domain.ErrDatabaseUnavailable
 	}
 
 	if err := tx.Where("id = ?", actor.ID).Delete(&actor).Error; err != nil {
 		tx.Rollback()
 		zap.L().Error("actor silinirken hata oluştu", zap.Error(err))
-		return errors.New("actor silme sorgusu çalıştırılırken hata oluştu")
+		return domain.ErrDatabaseUnavailable
 	}
 
 	if err := tx.Commit().Error; err != nil {
 		zap.L().Error("transaction commit hatası", zap.Error(err))
-		return err
+		return domain.ErrDatabaseUnavailable
 	}
 
 	return nil
@@ -196,16 +323,21 @@ func (h *PostgresHandler) GetActor(ctx context.Context, id string) (*domain.Acto
 	ctx, span := h.tracer.Start(ctx, "GetActor")
 	defer span.End()
 
+	actorID, err := domain.ParseActorID(id)
+	if err != nil {
+		return nil, err
+	}
+
 	var actor domain.Actor
-	err := h.db.WithContext(ctx).Model(&domain.Actor{}).Where("id = ?", id).First(&actor).Error
+	err = h.db.WithContext(ctx).Model(&domain.Actor{}).Where("id = ?", actorID).First(&actor).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		zap.L().Info("Bu id'li kullanıcı bulunmamaktadır", zap.String("id", id))
-		return nil, errors.New("bu id'li kullanıcı bulunmamaktadır")
+		return nil, domain.ErrActorNotFound
 	}
 	if err != nil {
 		zap.L().Error("Sorgu çalıştırılırken hata oluştu", zap.Error(err))
-		return nil, errors.New("sorgu çalıştırılırken hata oluştu")
+		return nil, domain.ErrDatabaseUnavailable
 	}
 
 	zap.L().Info("Veriler getirildi", zap.String("id", id))
@@ -216,10 +348,15 @@ func (h *PostgresHandler) UpdateActor(ctx context.Context, id, firstname, lastna
 	ctx, span := h.tracer.Start(ctx, "UpdateActor")
 	defer span.End()
 
+	actorID, err := domain.ParseActorID(id)
+	if err != nil {
+		return err
+	}
+
 	tx := h.db.Model(&domain.Actor{}).WithContext(ctx).Begin()
 	if tx.Error != nil {
 		zap.L().Error("transaction başlatılamadı", zap.Error(tx.Error))
-		return errors.New("transaction başlatılamadı")
+		return domain.ErrDatabaseUnavailable
 	}
 	defer func() {
 		if r := recover(); r != nil {
@@ -228,19 +365,19 @@ func (h *PostgresHandler) UpdateActor(ctx context.Context, id, firstname, lastna
 	}()
 
 	var actor domain.Actor
-	if err := tx.Where("id = ?", id).First(&actor).Error; err != nil {
+	if err := tx.Where("id = ?", actorID).First(&actor).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			tx.Rollback()
-			return errors.New("bu id'ye ait kullanıcı bulunmamaktadır")
+			return domain.ErrActorNotFound
 		}
 		tx.Rollback()
 		zap.L().Error("actor sorgusu hatası", zap.Error(err))
-		return errors.New("actor sorgusu hatası")
+		return domain.ErrDatabaseUnavailable
 	}
 
 	if actor.FirstName == firstname && actor.LastName == lastname {
 		tx.Rollback()
-		return errors.New("bu bilgilere ait kullanıcı zaten mevcut")
+		return domain.ErrActorUnchanged
 	}
 
 	actor.FirstName = firstname
@@ -248,15 +385,85 @@ func (h *PostgresHandler) UpdateActor(ctx context.Context, id, firstname, lastna
 
 	if err := tx.Where("id = ?", actor.ID).Updates(&actor).Error; err != nil {
 		tx.Rollback()
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrActorAlreadyExists
+		}
 		zap.L().Error("güncelleme yapılamadı", zap.Error(err))
-		return errors.New("güncelleme yapılamadı")
+		return domain.ErrDatabaseUnavailable
 	}
 
 	if err := tx.Commit().Error; err != nil {
 		zap.L().Error("transaction commit hatası", zap.Error(err))
-		return err
+		return domain.ErrDatabaseUnavailable
 	}
 
 	zap.L().Info("actor güncellendi", zap.String("id", id))
 	return nil
 }
+
+func (h *PostgresHandler) PatchActor(ctx context.Context, id string, patch domain.ActorPatch) (*domain.Actor, error) {
+	ctx, span := h.tracer.Start(ctx, "PatchActor")
+	defer span.End()
+
+	if patch.Empty() {
+		actor, err := h.GetActor(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !patch.If.Match(*actor) {
+			return nil, domain.ErrPatchTestFailed
+		}
+		return actor, nil
+	}
+
+	actorID, err := domain.ParseActorID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]any, 2)
+	if patch.FirstName != nil {
+		values["first_name"] = *patch.FirstName
+	}
+	if patch.LastName != nil {
+		values["last_name"] = *patch.LastName
+	}
+
+	// Güncelleme tek UPDATE ... RETURNING ile yapılır; patch'te olmayan
+	// alanlara dokunulmadığı için eşzamanlı diğer güncellemeler ezilmez.
+	// Koşul WHERE'e eklenir, böylece kontrol ve yazma atomiktir.
+	// last_update, actors_last_update trigger'ı ile güncellenir.
+	var actor domain.Actor
+	db := h.db.WithContext(ctx).Model(&actor).Clauses(clause.Returning{}).Where("id = ?", actorID)
+	c := patch.If
+	if c.FirstName != nil {
+		db = db.Where("first_name = ?", *c.FirstName)
+	}
+	if c.LastName != nil {
+		db = db.Where("last_name = ?", *c.LastName)
+	}
+	if c.LastUpdate != nil {
+		db = db.Where("last_update = ?", *c.LastUpdate)
+	}
+	res := db.Updates(values)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, domain.ErrActorAlreadyExists
+		}
+		zap.L().Error("actor güncellenemedi", zap.Error(err))
+		return nil, domain.ErrDatabaseUnavailable
+	}
+	if res.RowsAffected == 0 {
+		if patch.If.Empty() {
+			return nil, domain.ErrActorNotFound
+		}
+		// Satır yoksa bulunamadı, varsa koşul sağlanmamıştır.
+		if _, err := h.GetActor(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrPatchTestFailed
+	}
+
+	zap.L().Info("actor güncellendi", zap.String("id", id))
+	return &actor, nil
+}
@@ -0,0 +1,18 @@
+package postgresql_test
+
+import (
+	"testing"
+
+	"github.com/EmreZURNACI/apistack/app/actor"
+	"github.com/EmreZURNACI/apistack/app/actor/repositorytest"
+	"github.com/EmreZURNACI/apistack/infra/postgresql/postgrestest"
+)
+
+func TestPostgresHandler(t *testing.T) {
+	handler, truncate := postgrestest.Open(t)
+
+	repositorytest.Run(t, func(t *testing.T) actor.Repository {
+		truncate(t)
+		return handler
+	})
+}
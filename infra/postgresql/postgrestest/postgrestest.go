@@ -0,0 +1,89 @@
+// Package postgrestest, testlerin gerçek bir Postgres'e bağlanmasını sağlar.
+// Bağlantı bilgileri ortam değişkenlerinden okunur; POSTGRES_TEST_HOST
+// tanımlı değilse testler atlanır. Testler actors tablosunu boşalttığı için
+// varsayılan veritabanı apistack_test'tir; uygulamanın veritabanına
+// yönlendirilen testler başarısız olur.
+//
+//	createdb -U postgres apistack_test
+//	POSTGRES_TEST_HOST=localhost POSTGRES_TEST_PASSWORD=123 go test ./...
+package postgrestest
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/EmreZURNACI/apistack/infra/postgresql"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	testDB = "apistack_test"
+	// applicationDB, config.yaml'daki uygulama veritabanıdır.
+	applicationDB = "dvdrental"
+)
+
+// Open, migration'ları uygulayıp PostgresHandler döner. Dönen truncate,
+// tabloyu boşaltıp id sequence'ini sıfırlar; her alt testten önce
+// çağrılmalıdır.
+func Open(t *testing.T) (handler *postgresql.PostgresHandler, truncate func(t *testing.T)) {
+	t.Helper()
+
+	host := os.Getenv("POSTGRES_TEST_HOST")
+	if host == "" {
+		t.Skip("POSTGRES_TEST_HOST tanımlı değil; Postgres testleri atlandı")
+	}
+
+	db := env("POSTGRES_TEST_DB", testDB)
+	if db == applicationDB || db == viper.GetString("database.db") {
+		t.Fatalf("POSTGRES_TEST_DB=%s uygulamanın veritabanı; testler actors tablosunu boşaltır, %s gibi ayrı bir veritabanı kullanın", db, testDB)
+	}
+
+	viper.Set("database.hostname", host)
+	viper.Set("database.port", env("POSTGRES_TEST_PORT", "5432"))
+	viper.Set("database.user", env("POSTGRES_TEST_USER", "postgres"))
+	viper.Set("database.password", os.Getenv("POSTGRES_TEST_PASSWORD"))
+	viper.Set("database.db", db)
+
+	migrator, err := postgresql.NewMigrator()
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	defer migrator.Close()
+	if _, err := migrator.Up(t.Context()); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+
+	handler, err = postgresql.GetPostgresHandler(otel.Tracer("test"))
+	if err != nil {
+		t.Fatalf("GetPostgresHandler: %v", err)
+	}
+	t.Cleanup(func() { handler.Close() })
+
+	conn, err := sql.Open("postgres", fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		viper.GetString("database.hostname"),
+		viper.GetString("database.port"),
+		viper.GetString("database.user"),
+		viper.GetString("database.password"),
+		viper.GetString("database.db")))
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return handler, func(t *testing.T) {
+		t.Helper()
+		if _, err := conn.ExecContext(t.Context(), "TRUNCATE actors RESTART IDENTITY"); err != nil {
+			t.Fatalf("truncate actors: %v", err)
+		}
+	}
+}
+
+func env(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 	"time"
 
 	"go.uber.org/zap"
@@ -26,6 +27,9 @@ func init() {
 	if err := viper.ReadInConfig(); err != nil {
 		log.Fatal("Config okunamadı")
 	}
+	// İmleç anahtarı gizli olduğu için config dosyasından değil yalnızca
+	// ortamdan okunur.
+	viper.Set("pagination.cursor_secret", os.Getenv("CURSOR_SECRET"))
 
 	log := zap.NewProductionConfig()                                         // or zap.NewDevelopmentConfig() or any other zap.Config
 	log.EncoderConfig.EncodeTime = zapcore.TimeEncoderOfLayout(time.RFC3339) // or time.RubyDate or "2006-01-02 15:04:05" or even freaking time.Kitchen
@@ -37,16 +41,21 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			zap.L().Fatal("migrate failed", zap.Error(err))
+		}
+		return
+	}
 
 	// Alınan traceler fonksiyonşardan geçirilecek
-	tp := initTracer("stackapi")
-	defer func() {
-		if tp != nil {
-			_ = tp.Shutdown(context.Background())
-		}
-	}()
+	var hooks []server.Hook
+	if tp := initTracer("stackapi"); tp != nil {
+		// Span'ler Postgres ve Redis kapandıktan sonra flush edilir.
+		hooks = append(hooks, server.Hook{Name: "tracer", Close: tp.Shutdown})
+	}
 
-	server.Route()
+	server.Route(hooks...)
 }
 
 func initTracer(service_name string) *sdktrace.TracerProvider {
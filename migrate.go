@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/EmreZURNACI/apistack/infra/postgresql"
+	"go.uber.org/zap"
+)
+
+// runMigrate, "migrate up|down|status" komutlarını çalıştırır.
+func runMigrate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("kullanım: %s migrate up|down|status", os.Args[0])
+	}
+
+	migrator, err := postgresql.NewMigrator()
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		zap.L().Info("migrate up tamamlandı", zap.Int("applied", len(applied)))
+	case "down":
+		reverted, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		if reverted == nil {
+			zap.L().Info("geri alınacak migration yok")
+			return nil
+		}
+		zap.L().Info("migrate down tamamlandı", zap.Int64("version", reverted.Version))
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("bilinmeyen migrate komutu: %s", args[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Hook, sunucu durduktan sonra kapatılacak bir kaynaktır.
+type Hook struct {
+	Name  string
+	Close func(ctx context.Context) error
+}
+
+// Lifecycle, sunucuyu arka planda başlatır, SIGINT/SIGTERM gelince
+// istekleri boşaltır ve kayıtlı kaynakları eklenme sırasıyla kapatır.
+// İstek boşaltma ve kaynak kapatma için ayrı ayrı timeout kadar süre
+// tanınır; istekler süreyi tüketse de span'ler ve bağlantılar kapatılır.
+type Lifecycle struct {
+	app     *fiber.App
+	timeout time.Duration
+	hooks   []Hook
+}
+
+func NewLifecycle(app *fiber.App, timeout time.Duration) *Lifecycle {
+	return &Lifecycle{
+		app:     app,
+		timeout: timeout,
+	}
+}
+
+func (l *Lifecycle) OnShutdown(hooks ...Hook) {
+	l.hooks = append(l.hooks, hooks...)
+}
+
+// Run, sinyal gelene veya listener hata verene kadar bloklar.
+func (l *Lifecycle) Run(addr string) error {
+	listenErr := make(chan error, 1)
+	go func() {
+		listenErr <- l.app.Listen(addr)
+	}()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	var err error
+	select {
+	case sig := <-sigChan:
+		zap.L().Info("shutdown signal received", zap.String("signal", sig.String()))
+	case err = <-listenErr:
+		zap.L().Error("server stopped unexpectedly", zap.Error(err))
+	}
+
+	return errors.Join(err, l.shutdown())
+}
+
+func (l *Lifecycle) shutdown() error {
+	zap.L().Info("Shutting down server", zap.Duration("timeout", l.timeout))
+
+	var errs []error
+	if err := l.app.ShutdownWithTimeout(l.timeout); err != nil {
+		zap.L().Error("server shutdown failed", zap.Error(err))
+		errs = append(errs, err)
+	}
+	errs = append(errs, l.Close())
+
+	zap.L().Info("Server gracefully stopped")
+	return errors.Join(errs...)
+}
+
+// Close, kayıtlı hook'ları eklenme sırasıyla kendi timeout'larıyla
+// çalıştırır. Sunucu hiç başlamadıysa açılan kaynakları kapatmak için
+// doğrudan çağrılabilir.
+func (l *Lifecycle) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+
+	var errs []error
+	for _, hook := range l.hooks {
+		if err := hook.Close(ctx); err != nil {
+			zap.L().Error("shutdown hook failed", zap.String("hook", hook.Name), zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+		zap.L().Info("shutdown hook completed", zap.String("hook", hook.Name))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TestShutdownHooksAfterSlowDrain, istek boşaltma tüm süreyi tüketse de
+// hook'ların süresi dolmamış bir context aldığını doğrular.
+func TestShutdownHooksAfterSlowDrain(t *testing.T) {
+	const timeout = 100 * time.Millisecond
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	app := fiber.New()
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		close(started)
+		<-release
+		return nil
+	})
+	defer close(release)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go app.Listener(ln)
+	go http.Get("http://" + ln.Addr().String() + "/slow")
+	<-started
+
+	var hookErr error
+	called := false
+	lifecycle := NewLifecycle(app, timeout)
+	lifecycle.OnShutdown(Hook{Name: "tracer", Close: func(ctx context.Context) error {
+		called = true
+		hookErr = ctx.Err()
+		return nil
+	}})
+	lifecycle.shutdown()
+
+	if !called {
+		t.Fatal("hook was not called")
+	}
+	if hookErr != nil {
+		t.Fatalf("hook context already done after drain: %v", hookErr)
+	}
+}
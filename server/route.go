@@ -1,14 +1,23 @@
 package server
 
 import (
-	"os"
-	"os/signal"
-	"syscall"
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"time"
 
+	actorapp "github.com/EmreZURNACI/apistack/app/actor"
+	healthcheckapp "github.com/EmreZURNACI/apistack/app/healthcheck"
+	"github.com/EmreZURNACI/apistack/cache"
+	"github.com/EmreZURNACI/apistack/cache/lru"
 	"github.com/EmreZURNACI/apistack/cache/redis"
 	"github.com/EmreZURNACI/apistack/controller/actor"
+	"github.com/EmreZURNACI/apistack/controller/admin"
+	"github.com/EmreZURNACI/apistack/controller/apierror"
 	"github.com/EmreZURNACI/apistack/controller/healthcheck"
+	"github.com/EmreZURNACI/apistack/i18n"
+	"github.com/EmreZURNACI/apistack/infra/memory"
 	"github.com/EmreZURNACI/apistack/infra/postgresql"
 	"github.com/spf13/viper"
 
@@ -22,61 +31,159 @@ import (
 
 var tracer = otel.Tracer("stackapi")
 
-func Route() {
+// Route, sunucuyu kurup çalıştırır. hooks, sunucu ve kendi açtığı
+// bağlantılar kapandıktan sonra sırayla çağrılır; kurulum başarısız olsa da
+// çalıştırılırlar.
+func Route(hooks ...Hook) {
 
 	server := fiber.New(fiber.Config{
 		IdleTimeout:  5 * time.Minute,
 		WriteTimeout: 5 * time.Minute,
 		ReadTimeout:  5 * time.Minute,
 		Concurrency:  1024 * 1024,
+		ErrorHandler: apierror.Handler,
 	})
+	lifecycle := NewLifecycle(server, shutdownTimeout())
 
-	handler, err := postgresql.GetPostgresHandler(tracer)
+	err := mount(server, lifecycle)
+	lifecycle.OnShutdown(hooks...)
 	if err != nil {
-		zap.L().Error("Error getting postgres handler", zap.Error(err))
+		zap.L().Error("server setup failed", zap.Error(err))
+		if err := lifecycle.Close(); err != nil {
+			zap.L().Error("closing resources failed", zap.Error(err))
+		}
 		return
 	}
 
+	zap.L().Info("server started...", zap.Int("port", viper.GetInt("server.port")))
+	if err := lifecycle.Run(":" + viper.GetString("server.port")); err != nil {
+		zap.L().Error("server stopped with errors", zap.Error(err))
+	}
+}
+
+// mount, bağlantıları açıp route'ları kaydeder. Açılan her kaynak kapatılmak
+// üzere lifecycle'a eklenir; hata dönerse o ana kadar eklenenler açıktır.
+func mount(server *fiber.App, lifecycle *Lifecycle) error {
+	// İmleç içeren liste yanıtları Redis'te replikalar arasında paylaşılır ve
+	// istemciler imleci herhangi bir replikaya gönderebilir; rastgele,
+	// süreç başına bir anahtarla bu imleçler diğer replikalarda ve yeniden
+	// başlatmadan sonra reddedilir.
+	if viper.GetString("pagination.cursor_secret") == "" {
+		return errors.New("CURSOR_SECRET is required when the cache is shared")
+	}
+
+	// Hook'lar eklenme sırasıyla çalışır; warm-up, kullandığı Postgres ve
+	// Redis kapanmadan önce durdurulur.
+	warmup := newWarmUp()
+	lifecycle.OnShutdown(Hook{Name: "cache warm-up", Close: warmup.Close})
+
+	handler, err := newRepository()
+	if err != nil {
+		return fmt.Errorf("getting repository: %w", err)
+	}
+	if closer, ok := handler.(io.Closer); ok {
+		lifecycle.OnShutdown(Hook{Name: "database", Close: func(context.Context) error { return closer.Close() }})
+	}
+
 	cacher, err := redis.Connection()
 	if err != nil {
-		zap.L().Error("Error getting postgres handler", zap.Error(err))
-		return
+		return fmt.Errorf("getting redis handler: %w", err)
 	}
 
-	actorController := actor.NewActorController(handler, cacher)
-	healthcheckController := healthcheck.NewHealthCheckController()
+	var store cache.Cache = cacher
+	if viper.GetBool("cache.local.enabled") {
+		tiered := newTieredCache(cacher)
+		listenCtx, stopListening := context.WithCancel(context.Background())
+		go func() {
+			if err := tiered.Listen(listenCtx); err != nil {
+				zap.L().Error("cache invalidation listener stopped", zap.Error(err))
+			}
+		}()
+		lifecycle.OnShutdown(Hook{Name: "cache listener", Close: func(context.Context) error {
+			stopListening()
+			return nil
+		}})
+		store = tiered
+	}
+	lifecycle.OnShutdown(Hook{Name: "redis", Close: func(context.Context) error { return cacher.Close() }})
+
+	actorController := actor.NewActorController(handler, store)
+
+	// Warm-up sunucu dinlemeye başlarken arka planda çalışır; bitene kadar
+	// /readiness 503 döner.
+	warmup.start(actorController)
+
+	healthcheckController := healthcheck.NewHealthCheckController(map[string]healthcheckapp.Checker{
+		"redis": cacher,
+	}, map[string]healthcheckapp.Checker{
+		"cache_warmup": warmup,
+	})
 
 	server.Use(otelfiber.Middleware())
+	server.Use(i18n.New())
 
 	v1 := server.Group("/v1/actors")
 
 	server.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
 	server.Get("/healthcheck", healthcheckController.HealthCheck)
+	server.Get("/readiness", healthcheckController.Readiness)
 	v1.Get("/", actorController.GetActors)
 	v1.Get("/:id", actorController.GetActor)
 	v1.Post("/", actorController.CreateActor)
 	v1.Put("/:id", actorController.UpdateActor)
+	v1.Patch("/:id", actorController.PatchActor)
 	v1.Delete("/:id", actorController.DeleteActor)
 
-	zap.L().Info("server started...", zap.Int("port", viper.GetInt("server.port")))
-	if err := server.Listen(":" + viper.GetString("server.port")); err != nil {
-		zap.L().Fatal("server stopped", zap.Error(err))
+	// Admin route'ları yalnızca admin.token ayarlıysa açılır.
+	if token := viper.GetString("admin.token"); token != "" {
+		adminController := admin.NewAdminController(cacher, store)
+		adminCache := server.Group("/admin/cache", admin.Auth(token))
+		adminCache.Get("/keys", adminController.ListCacheKeys)
+		adminCache.Post("/flush", adminController.FlushCache)
+		adminCache.Delete("/:key", adminController.DeleteCacheKey)
+	} else {
+		zap.L().Warn("admin.token is empty, admin routes disabled")
 	}
 
-	GracefulShutdown(server)
+	return nil
 }
 
-func GracefulShutdown(app *fiber.App) {
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+func shutdownTimeout() time.Duration {
+	if timeout := viper.GetDuration("server.shutdown_timeout"); timeout > 0 {
+		return timeout
+	}
+	return 30 * time.Second
+}
 
-	<-sigChan
+// newTieredCache, Redis'in önüne config'teki sınırlarla yerel bir LRU koyar.
+func newTieredCache(remote *redis.Handler) *cache.Tiered {
+	local := lru.New(viper.GetInt("cache.local.max_entries"), viper.GetInt64("cache.local.max_bytes"))
 
-	zap.L().Sugar().Info("Shutting down server")
+	ttl := viper.GetDuration("cache.local.ttl")
+	if ttl <= 0 {
+		ttl = 10 * time.Second
+	}
 
-	if err := app.Shutdown(); err != nil {
-		zap.L().Sugar().Error("Shutting down server. : %s", zap.Error(err))
+	channel := viper.GetString("cache.local.channel")
+	if channel == "" {
+		channel = "cache:invalidate"
 	}
-	zap.L().Sugar().Info("Server gracefully stopped")
 
+	return cache.NewTiered(local, remote, ttl, channel)
+}
+
+// newRepository, database.driver ayarına göre aktör deposunu seçer.
+func newRepository() (actorapp.Repository, error) {
+	switch driver := viper.GetString("database.driver"); driver {
+	case "", "postgres":
+		handler, err := postgresql.GetPostgresHandler(tracer)
+		if err != nil {
+			return nil, err
+		}
+		return handler, nil
+	case "memory":
+		return memory.GetMemoryHandler(tracer), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", driver)
+	}
 }
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestRouteRequiresCursorSecret, server paketinin tüm controller'larıyla
+// birlikte yüklenebildiğini ve imleç anahtarı olmadan sunucunun
+// başlamadığını doğrular.
+func TestRouteRequiresCursorSecret(t *testing.T) {
+	viper.Set("pagination.cursor_secret", "")
+	t.Cleanup(viper.Reset)
+
+	// Kurulum ilk adımda durmalı; aksi halde Route dinlemeye başlar ve
+	// test bloklanır.
+	Route()
+}
+
+// TestRouteClosesHooksOnSetupError, kurulum başarısız olduğunda dışarıdan
+// verilen hook'ların (ör. tracer) yine de çalıştığını doğrular.
+func TestRouteClosesHooksOnSetupError(t *testing.T) {
+	viper.Set("pagination.cursor_secret", "")
+	t.Cleanup(viper.Reset)
+
+	closed := false
+	Route(Hook{Name: "tracer", Close: func(context.Context) error {
+		closed = true
+		return nil
+	}})
+	if !closed {
+		t.Fatal("hook was not run after setup failed")
+	}
+}
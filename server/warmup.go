@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/EmreZURNACI/apistack/controller/actor"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// warmUp, cache warm-up'ının bitip bitmediğini readiness'e bildirir.
+type warmUp struct {
+	done    atomic.Bool
+	cancel  context.CancelFunc
+	stopped chan struct{}
+}
+
+func newWarmUp() *warmUp {
+	return &warmUp{stopped: make(chan struct{})}
+}
+
+// start, warm-up'ı arka planda başlatır.
+func (w *warmUp) start(controller *actor.ActorController) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	go func() {
+		defer close(w.stopped)
+		w.run(ctx, controller)
+	}()
+}
+
+// Close, warm-up'ı iptal eder ve bitmesini bekler. Warm-up Postgres ve
+// Redis'i kullandığı için bu hook onlardan önce çalışmalıdır.
+func (w *warmUp) Close(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+	select {
+	case <-w.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *warmUp) Healthy(ctx context.Context) bool {
+	return w.done.Load()
+}
+
+// run, cache.warmup.timeout içinde biten ya da bitmeyen warm-up'tan sonra
+// servisi hazır işaretler; warm-up'ın başarısız olması servisi trafikten
+// uzak tutmaz.
+func (w *warmUp) run(ctx context.Context, controller *actor.ActorController) {
+	defer w.done.Store(true)
+
+	if !viper.GetBool("cache.warmup.enabled") {
+		return
+	}
+
+	timeout := viper.GetDuration("cache.warmup.timeout")
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	if err := controller.WarmUp(ctx); err != nil {
+		zap.L().Warn("cache warm-up failed", zap.Error(err))
+		return
+	}
+	zap.L().Info("cache warm-up finished", zap.Duration("took", time.Since(start)))
+}